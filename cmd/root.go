@@ -20,6 +20,12 @@ var rootCmd = &cobra.Command{
 	SilenceUsage:  true,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		utils.ShowBanner()
+		if err := commands.LoadInflections(); err != nil {
+			log.Printf("Error loading inflections: %v", err)
+		}
+		if err := commands.LoadFieldTypes(); err != nil {
+			log.Printf("Error loading field types: %v", err)
+		}
 	},
 	DisableAutoGenTag: true,
 	Example: `
@@ -29,6 +35,9 @@ silverinha create my-new-project
 # To create a new a model named 'modelExample':
 silverinha model modelExample
 
+# To apply the pending migrations:
+silverinha migration up
+
 # To see available commands and usage:
 silverinha --help
 `,
@@ -79,7 +88,24 @@ var modelCmd = &cobra.Command{
 			return
 		}
 
-		err := commands.GenerateModel(modelName)
+		options := commands.DefaultModelOptions()
+		if hardDelete, _ := cmd.Flags().GetBool("hard-delete"); hardDelete {
+			options.SoftDelete = false
+		}
+		if key, _ := cmd.Flags().GetString("key"); key != "" {
+			if _, err := commands.GetKeyType(key); err != nil {
+				log.Printf("Error generating model: %v", err)
+				return
+			}
+			options.Key = key
+		}
+
+		var err error
+		if sample, _ := cmd.Flags().GetString("from-json"); sample != "" {
+			err = commands.GenerateModelFromJSON(modelName, sample, options)
+		} else {
+			err = commands.GenerateModel(modelName, options)
+		}
 		if err != nil {
 			log.Printf("Error generating model: %v", err)
 		} else {
@@ -89,6 +115,396 @@ var modelCmd = &cobra.Command{
 	Example: `
 # Generate a model named 'User':
 silverinha model User
+
+# Generate a model whose records are removed instead of soft deleted:
+silverinha model AuditLog --hard-delete
+
+# Generate a model keyed by UUIDs instead of auto-incremented integers:
+silverinha model Session --key uuid
+
+# Infer the fields of a model, and of its nested objects, from a sample payload:
+silverinha model Invoice --from-json sample.json
+`,
+}
+
+// "migration" subcommand groups the versioned SQL migration commands
+var migrationCmd = &cobra.Command{
+	Use:   "migration",
+	Short: "Manage versioned SQL migrations",
+	Long:  `These commands generate SQL migrations from the model structs and apply them to the database.`,
+}
+
+// "migration generate" subcommand to write the migration for the model changes
+var migrationGenerateCmd = &cobra.Command{
+	Use:           "generate [name]",
+	Short:         "Generate a migration from the model changes",
+	Long:          `This command compares the model structs with the last recorded schema snapshot and writes timestamped up/down SQL files.`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		if dialect, _ := cmd.Flags().GetString("dialect"); dialect != "" {
+			if err := setProjectDialect(dialect); err != nil {
+				log.Printf("Error generating migration: %v", err)
+				return
+			}
+		}
+
+		if err := commands.GenerateMigration(name); err != nil {
+			log.Printf("Error generating migration: %v", err)
+		}
+	},
+	Example: `
+# Generate a migration for the pending model changes:
+silverinha migration generate add_age_to_users
+
+# Generate migrations for MySQL from now on:
+silverinha migration generate --dialect mysql
+`,
+}
+
+// "migration up" subcommand to apply the pending migrations
+var migrationUpCmd = &cobra.Command{
+	Use:           "up",
+	Short:         "Apply the pending migrations",
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		dsn, _ := cmd.Flags().GetString("dsn")
+		if err := commands.RunMigrations("up", dsn, 0); err != nil {
+			log.Printf("Error applying migrations: %v", err)
+		}
+	},
+}
+
+// "migration down" subcommand to revert the latest migrations
+var migrationDownCmd = &cobra.Command{
+	Use:           "down",
+	Short:         "Revert the latest applied migrations",
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		dsn, _ := cmd.Flags().GetString("dsn")
+		steps, _ := cmd.Flags().GetInt("steps")
+		if err := commands.RunMigrations("down", dsn, steps); err != nil {
+			log.Printf("Error reverting migrations: %v", err)
+		}
+	},
+}
+
+// "migration status" subcommand to list the migrations and whether they were applied
+var migrationStatusCmd = &cobra.Command{
+	Use:           "status",
+	Short:         "Show which migrations are applied",
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		dsn, _ := cmd.Flags().GetString("dsn")
+		if err := commands.RunMigrations("status", dsn, 0); err != nil {
+			log.Printf("Error reading migration status: %v", err)
+		}
+	},
+}
+
+// setProjectDialect stores the SQL dialect in the project configuration
+func setProjectDialect(dialect string) error {
+	if _, err := commands.GetDialect(dialect); err != nil {
+		return err
+	}
+
+	config, err := commands.LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+	config.Dialect = dialect
+	return commands.SaveProjectConfig(config)
+}
+
+// "factory" subcommand to generate the test-data factory of a model
+var factoryCmd = &cobra.Command{
+	Use:           "factory [model-name]",
+	Short:         "Generate a test-data factory for a model",
+	Long:          `This command generates a factory that builds model values filled with realistic fake data, with builders for its relationships.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := commands.GenerateFactory(args[0]); err != nil {
+			log.Printf("Error generating factory: %v", err)
+		} else {
+			fmt.Println("Factory generated successfully!")
+		}
+	},
+	Example: `
+# Generate the factory of the 'User' model:
+silverinha factory User
+`,
+}
+
+// "scaffold" subcommand to generate the layers of a hand-written model
+var scaffoldCmd = &cobra.Command{
+	Use:           "scaffold [model-name]",
+	Short:         "Generate the layers of an existing model struct",
+	Long:          `This command reads a hand-written struct of internal/app/domain/model and generates its inbound model, validator, outbound model, mappers, repository, service, mocks and handler, leaving the struct untouched.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := commands.ScaffoldModel(args[0]); err != nil {
+			log.Printf("Error scaffolding model: %v", err)
+		} else {
+			fmt.Println("Model scaffolded successfully!")
+		}
+	},
+	Example: `
+# Generate the layers of the hand-written 'Foo' struct:
+silverinha scaffold Foo
+`,
+}
+
+// "sync" subcommand to regenerate the layers derived from the model structs
+var syncCmd = &cobra.Command{
+	Use:           "sync",
+	Short:         "Regenerate the layers derived from the model structs",
+	Long:          `This command re-reads every model struct and regenerates its inbound model, validator, outbound model and mappers, reporting what changed per model. Repositories, services and handlers are left untouched.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := commands.SyncModels(); err != nil {
+			log.Printf("Error syncing models: %v", err)
+		} else {
+			fmt.Println("Models synced successfully!")
+		}
+	},
+	Example: `
+# Update the derived layers after adding a field to a model struct:
+silverinha sync
+`,
+}
+
+// "field" subcommand groups the commands editing the fields of an existing model
+var fieldCmd = &cobra.Command{
+	Use:   "field",
+	Short: "Add, remove or rename the fields of a model",
+	Long:  `These commands edit a model struct and update the inbound model, validator, outbound model, mappers, handler test body and factory derived from it.`,
+}
+
+var fieldAddCmd = &cobra.Command{
+	Use:   "add [model-name] [name:type[:modifier...]]",
+	Short: "Add a field to a model",
+	Long: `This command adds a field to a model struct. The modifiers are nullable, required, default=<value>,
+unique, index, index=<name> for a composite index, size=<n> for strings, precision=<n> and scale=<n> for decimals,
+json=<key> and column=<name> overriding the naming strategies of the project, description=<text> and
+example=<value> documenting the field in Swagger and the database, and readonly, writeonly or hidden leaving it
+out of the requests, the responses or both.
+Defaults and examples are checked against the type, and function calls like now() are used as SQL expressions.`,
+	Args:          cobra.ExactArgs(2),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrate, _ := cmd.Flags().GetBool("migration")
+		if err := commands.FieldAdd(args[0], args[1], migrate); err != nil {
+			log.Printf("Error adding field: %v", err)
+		} else {
+			fmt.Println("Field added successfully!")
+		}
+	},
+	Example: `
+# Add a nullable age to the 'User' model, with its migration:
+silverinha field add User age:int:nullable --migration
+
+# Add a required status with a default value:
+silverinha field add Order status:string:required:default=draft
+
+# Add an enum with a type and constants for its values:
+silverinha field add Post 'status:enum(draft,published,archived)'
+
+# Add a unique email of at most 120 characters:
+silverinha field add User email:string:unique:size=120
+
+# Add a time set by the database when it is not given:
+silverinha field add Post 'published_at:time.Time:default=CURRENT_TIMESTAMP'
+
+# Add a field with a description and an example shown in Swagger:
+silverinha field add Post 'summary:string:description=Short text: shown first:example=A day at the beach'
+
+# Add a secret accepted in the requests but never sent in the responses:
+silverinha field add User password_hash:string:writeonly
+`,
+}
+
+var fieldRemoveCmd = &cobra.Command{
+	Use:           "remove [model-name] [field-name]",
+	Short:         "Remove a field from a model",
+	Long:          `This command removes a field from a model struct, with the relationship it is the foreign key of.`,
+	Args:          cobra.ExactArgs(2),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrate, _ := cmd.Flags().GetBool("migration")
+		if err := commands.FieldRemove(args[0], args[1], migrate); err != nil {
+			log.Printf("Error removing field: %v", err)
+		} else {
+			fmt.Println("Field removed successfully!")
+		}
+	},
+	Example: `
+# Remove the age of the 'User' model:
+silverinha field remove User age
+`,
+}
+
+var fieldRenameCmd = &cobra.Command{
+	Use:           "rename [model-name] [field-name] [new-name]",
+	Short:         "Rename a field of a model",
+	Long:          `This command renames a field of a model struct. With --migration the column is renamed instead of dropped.`,
+	Args:          cobra.ExactArgs(3),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrate, _ := cmd.Flags().GetBool("migration")
+		if err := commands.FieldRename(args[0], args[1], args[2], migrate); err != nil {
+			log.Printf("Error renaming field: %v", err)
+		} else {
+			fmt.Println("Field renamed successfully!")
+		}
+	},
+	Example: `
+# Rename the age of the 'User' model to years, renaming its column:
+silverinha field rename User age years --migration
+`,
+}
+
+// "destroy" subcommand groups the commands undoing generators
+var destroyCmd = &cobra.Command{
+	Use:   "destroy",
+	Short: "Undo what a generator created",
+	Long:  `These commands delete generated files and remove the wiring injected for them.`,
+}
+
+var destroyModelCmd = &cobra.Command{
+	Use:           "model [model-name]",
+	Short:         "Delete a model and every layer generated for it",
+	Long:          `This command deletes the domain, inbound, validator, outbound, mapper, repository, service, mock, handler and factory files of a model and removes its wiring from services.go, handlers.go and runMigrations.`,
+	Args:          cobra.ExactArgs(1),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrate, _ := cmd.Flags().GetBool("migration")
+		if err := commands.DestroyModel(args[0], migrate); err != nil {
+			log.Printf("Error destroying model: %v", err)
+		} else {
+			fmt.Println("Model destroyed successfully!")
+		}
+	},
+	Example: `
+# Delete the 'User' model and generate the migration dropping its table:
+silverinha destroy model User --migration
+`,
+}
+
+// "rename" subcommand groups the refactoring commands
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename what a generator created",
+	Long:  `These commands rename generated files and every reference to them.`,
+}
+
+var renameModelCmd = &cobra.Command{
+	Use:           "model [model-name] [new-name]",
+	Short:         "Rename a model across every layer generated for it",
+	Long:          `This command renames the files, packages, types, constructors, routes, Swagger tags and table name of a model, and every reference to them in the wiring and in the other models.`,
+	Args:          cobra.ExactArgs(2),
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		migrate, _ := cmd.Flags().GetBool("migration")
+		if err := commands.RenameModel(args[0], args[1], migrate); err != nil {
+			log.Printf("Error renaming model: %v", err)
+		} else {
+			fmt.Println("Model renamed successfully!")
+		}
+	},
+	Example: `
+# Rename the 'Customer' model to 'Client' and generate the migration renaming its table:
+silverinha rename model Customer Client --migration
+`,
+}
+
+// "seed" subcommand to fill the database with fake records
+var seedCmd = &cobra.Command{
+	Use:           "seed",
+	Short:         "Seed the database with fake records",
+	Long:          `This command inserts fake records built by the model factories, generating the factory first when it is missing.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		modelName, _ := cmd.Flags().GetString("model")
+		count, _ := cmd.Flags().GetInt("count")
+		dsn, _ := cmd.Flags().GetString("dsn")
+
+		if err := commands.SeedDatabase(modelName, count, dsn); err != nil {
+			log.Printf("Error seeding database: %v", err)
+		}
+	},
+	Example: `
+# Insert 100 fake users:
+silverinha seed --model User --count 100
+
+# Insert 10 fake records of every model with a factory:
+silverinha seed
+`,
+}
+
+// "import" subcommand to generate models from an existing database schema
+var importCmd = &cobra.Command{
+	Use:           "import",
+	Short:         "Generate models from an existing database schema",
+	Long:          `This command reads CREATE TABLE statements from a SQL file, the schema of a SQLite database file or the schemas and paths of an OpenAPI 3 document, and generates the model and every layer of each table or schema.`,
+	Args:          cobra.NoArgs,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	Run: func(cmd *cobra.Command, args []string) {
+		ddlPath, _ := cmd.Flags().GetString("ddl")
+		sqlitePath, _ := cmd.Flags().GetString("sqlite")
+		openAPIPath, _ := cmd.Flags().GetString("openapi")
+
+		var err error
+		switch {
+		case ddlPath != "":
+			err = commands.ImportDDL(ddlPath)
+		case sqlitePath != "":
+			err = commands.ImportSQLite(sqlitePath)
+		case openAPIPath != "":
+			err = commands.ImportOpenAPI(openAPIPath)
+		default:
+			err = fmt.Errorf("set --ddl, --sqlite or --openapi")
+		}
+		if err != nil {
+			log.Printf("Error importing schema: %v", err)
+		} else {
+			fmt.Println("Schema imported successfully!")
+		}
+	},
+	Example: `
+# Generate the models of the tables created in a SQL script:
+silverinha import --ddl schema.sql
+
+# Generate the models of the tables of a SQLite database:
+silverinha import --sqlite app.db
+
+# Generate the models, validators and handlers described by an OpenAPI 3 document:
+silverinha import --openapi api.yaml
 `,
 }
 
@@ -126,12 +542,54 @@ var completionCmd = &cobra.Command{
 func init() {
 	// Add the completion command to rootCmd
 	rootCmd.AddCommand(completionCmd)
+
+	modelCmd.Flags().Bool("hard-delete", false, "Delete records permanently instead of soft deleting them")
+	modelCmd.Flags().String("from-json", "", "Sample JSON payload to infer the fields from")
+	modelCmd.Flags().String("key", "", "Primary key type (uint, int64, uuid, ulid), defaults to keyType in silveirinha.json")
+
+	// Add the migration subcommands and their flags
+	migrationGenerateCmd.Flags().String("dialect", "", "SQL dialect of the migrations (postgres, mysql, sqlite), saved in silveirinha.json")
+	migrationCmd.PersistentFlags().String("dsn", "", "Database connection string (defaults to $DATABASE_DSN)")
+	migrationDownCmd.Flags().Int("steps", 1, "Number of migrations to revert")
+	migrationCmd.AddCommand(migrationGenerateCmd, migrationUpCmd, migrationDownCmd, migrationStatusCmd)
+
+	// Add the import flags
+	importCmd.Flags().String("ddl", "", "SQL file with the CREATE TABLE statements to import")
+	importCmd.Flags().String("sqlite", "", "SQLite database file to import")
+	importCmd.Flags().String("openapi", "", "OpenAPI 3 document (JSON or YAML) to import")
+	importCmd.MarkFlagsMutuallyExclusive("ddl", "sqlite", "openapi")
+
+	// Add the field subcommands and their flags
+	fieldCmd.PersistentFlags().Bool("migration", false, "Generate the migration of the change")
+	fieldCmd.AddCommand(fieldAddCmd, fieldRemoveCmd, fieldRenameCmd)
+
+	// Add the destroy subcommands and their flags
+	destroyModelCmd.Flags().Bool("migration", false, "Generate the migration dropping the table")
+	destroyCmd.AddCommand(destroyModelCmd)
+
+	// Add the rename subcommands and their flags
+	renameModelCmd.Flags().Bool("migration", false, "Generate the migration renaming the table")
+	renameCmd.AddCommand(renameModelCmd)
+
+	// Add the seed flags
+	seedCmd.Flags().String("model", "", "Model to seed, all models with a factory when empty")
+	seedCmd.Flags().Int("count", 10, "Number of records to insert")
+	seedCmd.Flags().String("dsn", "", "Database connection string (defaults to $DATABASE_DSN)")
 }
 
 // Execute executes the root command
 func Execute() error {
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(modelCmd)
+	rootCmd.AddCommand(migrationCmd)
+	rootCmd.AddCommand(scaffoldCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(fieldCmd)
+	rootCmd.AddCommand(destroyCmd)
+	rootCmd.AddCommand(renameCmd)
+	rootCmd.AddCommand(factoryCmd)
+	rootCmd.AddCommand(seedCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.PersistentFlags().BoolP("version", "v", false, "Show the version of Silverinha")
 
 	if err := rootCmd.Execute(); err != nil {
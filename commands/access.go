@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// fieldAccesses are the ways a field can be restricted in the API: read-only fields are managed by the
+// server and ignored in requests, write-only fields are accepted but never sent, like secrets, and hidden
+// fields stay internal.
+var fieldAccesses = []string{"readonly", "writeonly", "hidden"}
+
+// Writable reports whether the field is accepted in the request bodies, in the inbound model.
+func (f ModelField) Writable() bool {
+	return f.Access != "readonly" && f.Access != "hidden"
+}
+
+// Readable reports whether the field is sent in the responses, in the outbound model.
+func (f ModelField) Readable() bool {
+	return f.Access != "writeonly" && f.Access != "hidden"
+}
+
+// inboundFields returns the fields of a spec accepted in the request bodies.
+func (s ModelSpec) inboundFields() []ModelField {
+	return slices.DeleteFunc(slices.Clone(s.Fields), func(field ModelField) bool { return !field.Writable() })
+}
+
+// outboundFields returns the fields of a spec sent in the responses.
+func (s ModelSpec) outboundFields() []ModelField {
+	return slices.DeleteFunc(slices.Clone(s.Fields), func(field ModelField) bool { return !field.Readable() })
+}
+
+// validateAccess checks the access of a field, and that it is not required when requests cannot set it.
+func validateAccess(field ModelField) error {
+	if field.Access != "" && !slices.Contains(fieldAccesses, field.Access) {
+		return fmt.Errorf("invalid access %q of %s, expected %s", field.Access, field.Name, strings.Join(fieldAccesses, ", "))
+	}
+	if field.Rules.Required && !field.Writable() {
+		return fmt.Errorf("%s is %s, it cannot be required in the requests", field.Name, field.Access)
+	}
+	return nil
+}
+
+// writeOutboundModelFile creates the outbound model file, the body of the responses: the ID, the fields
+// that are not write-only or hidden, and the timestamps. Relationships are left out, as they are not loaded.
+func writeOutboundModelFile(filePath string, spec ModelSpec, options ModelOptions) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	// Enums are the types of the model package
+	key := options.key()
+	fields := spec.outboundFields()
+	std, external := fieldImports(append(fields, ModelField{Type: key.GoType}))
+	if spec.Timestamps && !slices.Contains(std, "time") {
+		std = append(std, "time")
+		sort.Strings(std)
+	}
+	if slices.ContainsFunc(fields, func(field ModelField) bool { return field.Enum }) {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("error getting current working directory: %v", err)
+		}
+		external = append(external, filepath.Base(currentDir)+"/internal/app/domain/model")
+		sort.Strings(external)
+	}
+	writer.WriteString("package outbound\n\n")
+	writer.WriteString(importBlock(std, external))
+
+	writer.WriteString(fmt.Sprintf("type %s struct {\n", spec.Name))
+	writer.WriteString(fmt.Sprintf("\tID %s `json:\"%s\"`\n", key.GoType, jsonKey("ID")))
+	for _, field := range fields {
+		writer.WriteString(fieldDoc(field))
+		writer.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"%s`\n", field.Name, transportType(field), field.JSONName(), swaggerTag(field)))
+	}
+	if spec.Timestamps {
+		writer.WriteString(fmt.Sprintf("\tCreatedAt time.Time `json:\"%s\"`\n", jsonKey("CreatedAt")))
+		writer.WriteString(fmt.Sprintf("\tUpdatedAt time.Time `json:\"%s\"`\n", jsonKey("UpdatedAt")))
+	}
+	writer.WriteString("}\n")
+
+	writer.Flush()
+	return nil
+}
+
+// writeOutboundMapperFile generates the mapper file mapping the domain model to the outbound model.
+// Fields are copied by name when the model has them with the same type.
+func writeOutboundMapperFile(filePath, structName string) error {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current working directory: %v", err)
+	}
+	projectFolder := filepath.Base(currentDir)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating mapper file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	writer.WriteString(fmt.Sprintf(`package mapper
+
+import (
+	"reflect"
+	"%[1]s/internal/app/domain/model"
+	"%[1]s/internal/app/transport/outbound"
+)
+
+func %[2]sMapToOutbound(modelObj model.%[2]s) outbound.%[2]s {
+	var outboundObj outbound.%[2]s
+	modelValue := reflect.ValueOf(modelObj)
+	outboundValue := reflect.ValueOf(&outboundObj).Elem()
+
+	// Loop through each field in the outbound struct
+	for i := 0; i < outboundValue.NumField(); i++ {
+		fieldName := outboundValue.Type().Field(i).Name
+		modelField := modelValue.FieldByName(fieldName)
+
+		// If the field exists in the model with the same type, copy the value
+		if modelField.IsValid() && modelField.Type() == outboundValue.Field(i).Type() {
+			outboundValue.Field(i).Set(modelField)
+		}
+	}
+
+	return outboundObj
+}
+
+func %[2]sListMapToOutbound(modelObjs []*model.%[2]s) []outbound.%[2]s {
+	outboundObjs := make([]outbound.%[2]s, 0, len(modelObjs))
+	for _, modelObj := range modelObjs {
+		outboundObjs = append(outboundObjs, %[2]sMapToOutbound(*modelObj))
+	}
+	return outboundObjs
+}
+`, projectFolder, structName))
+
+	writer.Flush()
+	return nil
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+)
+
+// projectConfigFile is the silveirinha configuration file kept at the root of a generated project.
+const projectConfigFile = "silveirinha.json"
+
+// ProjectConfig holds the project-wide generator settings.
+type ProjectConfig struct {
+	// Dialect is the SQL dialect migrations are written for (postgres, mysql or sqlite).
+	Dialect string `json:"dialect"`
+
+	// Inflections are added to the default rules naming types, tables, routes and Swagger tags.
+	Inflections *InflectionConfig `json:"inflections,omitempty"`
+
+	// Types are registered besides the built-in field types, or replace the ones with the same Go type.
+	Types []FieldType `json:"types,omitempty"`
+
+	// KeyType is the primary key type of new models (uint, int64, uuid or ulid), uint when empty.
+	KeyType string `json:"keyType,omitempty"`
+
+	// Naming spells the JSON keys and columns of new fields, snake_case when empty.
+	Naming *NamingConfig `json:"naming,omitempty"`
+}
+
+// InflectionConfig holds the words of a project the default inflection rules get wrong.
+type InflectionConfig struct {
+	// Acronyms are written in capitals in type names, like "SKU" in "ProductSKU".
+	Acronyms []string `json:"acronyms,omitempty"`
+
+	// Irregular maps singular words to the plural no rule derives, like "cactus" to "cacti".
+	Irregular map[string]string `json:"irregular,omitempty"`
+
+	// Uncountable words have the same singular and plural, like "equipment".
+	Uncountable []string `json:"uncountable,omitempty"`
+}
+
+// DefaultProjectConfig returns the settings used when the project has no configuration file.
+func DefaultProjectConfig() ProjectConfig {
+	return ProjectConfig{Dialect: "postgres"}
+}
+
+// LoadProjectConfig reads the project configuration from the current directory.
+// Missing files and missing keys fall back to the defaults.
+func LoadProjectConfig() (ProjectConfig, error) {
+	config := DefaultProjectConfig()
+
+	content, err := os.ReadFile(projectConfigFile)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("error reading %s: %v", projectConfigFile, err)
+	}
+
+	if err := json.Unmarshal(content, &config); err != nil {
+		return config, fmt.Errorf("error parsing %s: %v", projectConfigFile, err)
+	}
+	if config.KeyType != "" {
+		if _, err := GetKeyType(config.KeyType); err != nil {
+			return config, fmt.Errorf("error in %s: %v", projectConfigFile, err)
+		}
+	}
+	if config.Naming != nil {
+		if err := config.Naming.validate(); err != nil {
+			return config, fmt.Errorf("error in %s: %v", projectConfigFile, err)
+		}
+	}
+	return config, nil
+}
+
+// SaveProjectConfig writes the project configuration to the current directory.
+func SaveProjectConfig(config ProjectConfig) error {
+	content, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %v", projectConfigFile, err)
+	}
+
+	if err := os.WriteFile(projectConfigFile, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", projectConfigFile, err)
+	}
+	return nil
+}
+
+// LoadInflections registers the inflections of the project configuration.
+func LoadInflections() error {
+	config, err := LoadProjectConfig()
+	if err != nil || config.Inflections == nil {
+		return err
+	}
+
+	inflection.AddAcronym(config.Inflections.Acronyms...)
+	for singular, plural := range config.Inflections.Irregular {
+		inflection.AddIrregular(singular, plural)
+	}
+	inflection.AddUncountable(config.Inflections.Uncountable...)
+	return nil
+}
+
+// LoadFieldTypes registers the field types of the project configuration.
+func LoadFieldTypes() error {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, fieldType := range config.Types {
+		if err := fieldType.validate(); err != nil {
+			return fmt.Errorf("error in %s: %v", projectConfigFile, err)
+		}
+		RegisterFieldType(fieldType)
+	}
+	return nil
+}
@@ -0,0 +1,490 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DDLTable is a table read from a CREATE TABLE statement.
+type DDLTable struct {
+	Name       string
+	Columns    []DDLColumn
+	PrimaryKey []string
+	Indexes    []DDLIndex // table level unique constraints and keys, and the indexes created on the table
+}
+
+// DDLIndex is an index or unique constraint of a table.
+type DDLIndex struct {
+	Name    string // empty when the constraint is not named
+	Columns []string
+	Unique  bool
+}
+
+// DDLColumn is a column read from a CREATE TABLE statement.
+type DDLColumn struct {
+	Name          string
+	Type          string // SQL type as declared, lowercased
+	NotNull       bool
+	PrimaryKey    bool
+	AutoIncrement bool
+	Default       string // default expression with string quotes and casts removed
+	References    string // referenced table of a foreign key
+	Unique        bool   // the column is declared UNIQUE
+	Comment       string // comment declared with the column or by a COMMENT ON COLUMN statement
+}
+
+// Column returns the column with the given name, or nil when the table does not have it.
+func (t *DDLTable) Column(name string) *DDLColumn {
+	for i := range t.Columns {
+		if strings.EqualFold(t.Columns[i].Name, name) {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+var commentOnColumnPattern = regexp.MustCompile(`(?is)^comment\s+on\s+column\s+(\S+)\s+is\s+(.*)$`)
+
+var createIndexPattern = regexp.MustCompile(`(?is)^create\s+(unique\s+)?index\s+(?:concurrently\s+)?(?:if\s+not\s+exists\s+)?([^\s(]+)\s+on\s+(?:only\s+)?([^\s(]+)\s*(?:using\s+\w+\s*)?\(([^()]*)\)`)
+
+var createTablePattern = regexp.MustCompile(`(?is)^create\s+(?:(?:global\s+|local\s+)?(?:temporary|temp)\s+)?table\s+(?:if\s+not\s+exists\s+)?([^\s(]+)\s*\((.*)\)[^)]*$`)
+
+// ParseDDL reads the CREATE TABLE statements of a SQL script.
+// Other statements are ignored, so a full schema dump can be given as is.
+func ParseDDL(script string) ([]DDLTable, error) {
+	var tables []DDLTable
+	for _, statement := range splitSQL(stripSQLComments(script), ';') {
+		// Comments of the columns of PostgreSQL follow the tables
+		if match := commentOnColumnPattern.FindStringSubmatch(strings.TrimSpace(statement)); match != nil {
+			parts := strings.Split(match[1], ".")
+			if len(parts) < 2 {
+				continue
+			}
+			tableName, columnName := unquoteIdentifier(parts[len(parts)-2]), unquoteIdentifier(parts[len(parts)-1])
+			for i := range tables {
+				if column := tables[i].Column(columnName); column != nil && strings.EqualFold(tables[i].Name, tableName) {
+					column.Comment = ddlDefault(strings.TrimSpace(match[2]))
+				}
+			}
+			continue
+		}
+
+		// Indexes created after their table, the unique ones included
+		if match := createIndexPattern.FindStringSubmatch(strings.TrimSpace(statement)); match != nil {
+			index := DDLIndex{Name: unquoteIdentifier(match[2]), Unique: match[1] != ""}
+			for _, column := range splitSQL(match[4], ',') {
+				index.Columns = append(index.Columns, unquoteIdentifier(strings.Fields(column)[0]))
+			}
+			for i := range tables {
+				if strings.EqualFold(tables[i].Name, unquoteIdentifier(match[3])) {
+					tables[i].Indexes = append(tables[i].Indexes, index)
+				}
+			}
+			continue
+		}
+
+		match := createTablePattern.FindStringSubmatch(strings.TrimSpace(statement))
+		if match == nil {
+			continue
+		}
+
+		table := DDLTable{Name: unquoteIdentifier(match[1])}
+		for _, definition := range splitSQL(match[2], ',') {
+			if err := parseTableDefinition(&table, strings.TrimSpace(definition)); err != nil {
+				return nil, fmt.Errorf("error parsing table %s: %v", table.Name, err)
+			}
+		}
+
+		// Table level primary keys are copied to their columns
+		for _, name := range table.PrimaryKey {
+			if column := table.Column(name); column != nil {
+				column.PrimaryKey = true
+				column.NotNull = true
+			}
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// parseTableDefinition reads a column or table constraint of a CREATE TABLE body into table.
+func parseTableDefinition(table *DDLTable, definition string) error {
+	if definition == "" {
+		return nil
+	}
+	tokens := sqlTokens(definition)
+	keyword := strings.ToLower(tokens[0])
+
+	// Named constraints: CONSTRAINT name PRIMARY KEY (...)
+	name := ""
+	if keyword == "constraint" && len(tokens) > 2 {
+		name = unquoteIdentifier(tokens[1])
+		tokens = tokens[2:]
+		keyword = strings.ToLower(tokens[0])
+	}
+
+	switch keyword {
+	case "primary":
+		table.PrimaryKey = append(table.PrimaryKey, parenthesizedNames(tokens)...)
+		return nil
+	case "foreign":
+		columns := parenthesizedNames(tokens)
+		for i, token := range tokens {
+			if strings.EqualFold(token, "references") && i+1 < len(tokens) && len(columns) == 1 {
+				if column := table.Column(columns[0]); column != nil {
+					column.References = unquoteIdentifier(strings.SplitN(tokens[i+1], "(", 2)[0])
+				}
+			}
+		}
+		return nil
+	case "unique", "index", "key":
+		// MySQL names its keys after the keyword: UNIQUE KEY name (...)
+		index := DDLIndex{Name: name, Columns: parenthesizedNames(tokens), Unique: keyword == "unique"}
+		for _, token := range tokens[1:] {
+			if before, _, found := strings.Cut(token, "("); found {
+				if before != "" {
+					index.Name = unquoteIdentifier(before)
+				}
+				break
+			}
+			if lower := strings.ToLower(token); lower != "key" && lower != "index" {
+				index.Name = unquoteIdentifier(token)
+			}
+		}
+		if len(index.Columns) > 0 {
+			table.Indexes = append(table.Indexes, index)
+		}
+		return nil
+	case "check", "fulltext", "spatial", "exclude":
+		return nil
+	}
+
+	if len(tokens) < 2 {
+		return fmt.Errorf("column %s has no type", tokens[0])
+	}
+
+	column := DDLColumn{Name: unquoteIdentifier(tokens[0])}
+
+	// The type runs until the first column constraint
+	i := 1
+	var typeTokens []string
+	for ; i < len(tokens); i++ {
+		if isColumnConstraint(tokens[i]) {
+			break
+		}
+		typeTokens = append(typeTokens, tokens[i])
+	}
+	column.Type = strings.ToLower(strings.Join(typeTokens, " "))
+
+	for ; i < len(tokens); i++ {
+		switch strings.ToLower(tokens[i]) {
+		case "not":
+			if i+1 < len(tokens) && strings.EqualFold(tokens[i+1], "null") {
+				column.NotNull = true
+				i++
+			}
+		case "primary":
+			column.PrimaryKey = true
+			column.NotNull = true
+		case "autoincrement", "auto_increment", "identity":
+			column.AutoIncrement = true
+		case "unique":
+			column.Unique = true
+		case "default":
+			if i+1 < len(tokens) {
+				column.Default = ddlDefault(tokens[i+1])
+				i++
+			}
+		case "references":
+			if i+1 < len(tokens) {
+				column.References = unquoteIdentifier(strings.SplitN(tokens[i+1], "(", 2)[0])
+				i++
+			}
+		case "comment":
+			if i+1 < len(tokens) {
+				column.Comment = ddlDefault(tokens[i+1])
+				i++
+			}
+		}
+	}
+
+	if strings.Contains(column.Type, "serial") {
+		column.AutoIncrement = true
+	}
+
+	table.Columns = append(table.Columns, column)
+	return nil
+}
+
+// isColumnConstraint reports whether token starts a column constraint.
+func isColumnConstraint(token string) bool {
+	switch strings.ToLower(token) {
+	case "not", "null", "primary", "default", "references", "unique", "check", "constraint",
+		"collate", "autoincrement", "auto_increment", "generated", "identity", "comment", "on", "as":
+		return true
+	}
+	return false
+}
+
+// ddlDefault normalizes a default expression: string literals are unquoted,
+// casts are dropped and sequence defaults, which GORM handles itself, are removed.
+func ddlDefault(expression string) string {
+	expression = strings.TrimSpace(expression)
+	for strings.HasPrefix(expression, "(") && strings.HasSuffix(expression, ")") {
+		expression = strings.TrimSpace(expression[1 : len(expression)-1])
+	}
+	if strings.HasPrefix(strings.ToLower(expression), "nextval") || strings.EqualFold(expression, "null") {
+		return ""
+	}
+	if index := strings.Index(expression, "::"); index > 0 {
+		expression = expression[:index]
+	}
+	if strings.HasPrefix(expression, "'") && strings.HasSuffix(expression, "'") && len(expression) >= 2 {
+		return strings.ReplaceAll(expression[1:len(expression)-1], "''", "'")
+	}
+	return expression
+}
+
+// parenthesizedNames returns the identifiers listed in the first parenthesized token.
+func parenthesizedNames(tokens []string) []string {
+	for _, token := range tokens {
+		start := strings.Index(token, "(")
+		if start < 0 || !strings.HasSuffix(token, ")") {
+			continue
+		}
+		var names []string
+		for _, name := range splitSQL(token[start+1:len(token)-1], ',') {
+			// Drop sort orders and lengths of index columns
+			names = append(names, unquoteIdentifier(strings.Fields(name)[0]))
+		}
+		return names
+	}
+	return nil
+}
+
+// unquoteIdentifier removes the quotes around an identifier and keeps the last part of a qualified name.
+func unquoteIdentifier(identifier string) string {
+	identifier = strings.TrimSpace(identifier)
+	parts := strings.Split(identifier, ".")
+	return strings.Trim(parts[len(parts)-1], "\"`[]")
+}
+
+// stripSQLComments removes -- and /* */ comments outside string literals. A -- comment ending the line of
+// a column definition, inside the parentheses of a statement, becomes a COMMENT clause of the column, the
+// way SQLite schemas, which have no COMMENT clause, document their columns.
+func stripSQLComments(script string) string {
+	var result []byte
+	inString := false
+	depth := 0
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\'':
+			inString = !inString
+		case inString:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == '-' && i+1 < len(script) && script[i+1] == '-':
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				end = len(script) - i
+			}
+			if depth > 0 {
+				result = columnLineComment(result, strings.TrimSpace(script[i+2:i+end]))
+			}
+			i += end
+		case c == '/' && i+1 < len(script) && script[i+1] == '*':
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				return string(result)
+			}
+			i += end + 3
+			continue
+		}
+		if i < len(script) {
+			result = append(result, script[i])
+		}
+	}
+	return string(result)
+}
+
+// columnLineComment adds comment as the COMMENT clause of the column definition ending the last line of
+// sql, before its comma. Lines without a definition, like the opening of a CREATE TABLE body, are left as is.
+func columnLineComment(sql []byte, comment string) []byte {
+	line := sql[bytes.LastIndexByte(sql, '\n')+1:]
+	definition := strings.TrimSpace(string(line))
+	if comment == "" || definition == "" || definition == "," || strings.HasSuffix(definition, "(") {
+		return sql
+	}
+	end := len(sql) - len(line) + bytes.LastIndexFunc(line, func(r rune) bool { return !unicode.IsSpace(r) }) + 1
+	if sql[end-1] == ',' {
+		end--
+	}
+	clause := " COMMENT " + sqlQuote(comment)
+	return append(sql[:end:end], append([]byte(clause), sql[end:]...)...)
+}
+
+// splitSQL splits text on separator, ignoring separators inside parentheses, quotes and identifiers.
+func splitSQL(text string, separator byte) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			quote = ']'
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == separator && depth == 0:
+			parts = append(parts, text[start:i])
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		parts = append(parts, text[start:])
+	}
+	return parts
+}
+
+// sqlTokens splits a definition on whitespace, keeping parenthesized groups and quoted text
+// attached to the token they follow, so "varchar (255)" gives "varchar(255)".
+func sqlTokens(definition string) []string {
+	var tokens []string
+	var current strings.Builder
+	depth := 0
+	var quote byte
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	for i := 0; i < len(definition); i++ {
+		c := definition[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			quote = ']'
+		case c == '(':
+			// Glue the group to the previous token unless it is a keyword like CHECK or DEFAULT
+			if depth == 0 && current.Len() == 0 && len(tokens) > 0 && !isColumnConstraint(tokens[len(tokens)-1]) {
+				current.WriteString(tokens[len(tokens)-1])
+				tokens = tokens[:len(tokens)-1]
+			}
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && (c == ' ' || c == '\t' || c == '\n' || c == '\r'):
+			flush()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	flush()
+	return tokens
+}
+
+// ddlTypeArguments returns the numbers in the parentheses of a SQL type, like the size of varchar(120)
+// or the precision and scale of numeric(10,2), as many as the type has.
+func ddlTypeArguments(sqlType string) []int {
+	start, end := strings.Index(sqlType, "("), strings.Index(sqlType, ")")
+	if start < 0 || end < start {
+		return nil
+	}
+	var numbers []int
+	for _, argument := range strings.Split(sqlType[start+1:end], ",") {
+		number, err := strconv.Atoi(strings.TrimSpace(argument))
+		if err != nil {
+			return nil
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
+
+// ddlGoType maps a SQL column type to the Go type of the model field.
+// Unknown types fall back to SQLite's type affinity rules.
+func ddlGoType(sqlType string) string {
+	unsigned := strings.Contains(sqlType, "unsigned")
+	base := strings.Fields(strings.SplitN(sqlType, "(", 2)[0])
+	name := ""
+	if len(base) > 0 {
+		name = base[0]
+	}
+	if strings.HasPrefix(sqlType, "double precision") {
+		name = "double"
+	}
+	if strings.HasPrefix(sqlType, "timestamp") || strings.HasPrefix(sqlType, "time ") {
+		name = "timestamp"
+	}
+
+	integer := func(goType string) string {
+		if unsigned {
+			return "u" + goType
+		}
+		return goType
+	}
+
+	switch name {
+	case "bool", "boolean":
+		return "bool"
+	case "tinyint":
+		if strings.HasPrefix(sqlType, "tinyint(1)") {
+			return "bool"
+		}
+		return integer("int8")
+	case "smallint", "int2", "smallserial", "serial2":
+		return integer("int16")
+	case "mediumint", "int", "integer", "int4", "serial", "serial4":
+		return integer("int")
+	case "bigint", "int8", "bigserial", "serial8":
+		return integer("int64")
+	case "real", "float4":
+		return "float32"
+	case "decimal", "numeric":
+		return "decimal.Decimal"
+	case "double", "float", "float8", "money":
+		return "float64"
+	case "date", "datetime", "timestamp", "timestamptz", "time":
+		return "time.Time"
+	case "blob", "bytea", "binary", "varbinary", "longblob", "mediumblob", "tinyblob":
+		return "[]byte"
+	case "uuid":
+		return "uuid.UUID"
+	case "char", "varchar", "character", "nchar", "nvarchar", "text", "tinytext", "mediumtext", "longtext",
+		"clob", "json", "jsonb", "enum", "set", "citext", "inet", "cidr", "xml":
+		return "string"
+	}
+
+	switch {
+	case strings.Contains(sqlType, "int"):
+		return "int64"
+	case strings.Contains(sqlType, "char"), strings.Contains(sqlType, "clob"), strings.Contains(sqlType, "text"):
+		return "string"
+	case sqlType == "", strings.Contains(sqlType, "blob"):
+		return "[]byte"
+	case strings.Contains(sqlType, "real"), strings.Contains(sqlType, "floa"), strings.Contains(sqlType, "doub"):
+		return "float64"
+	}
+	return "string"
+}
@@ -0,0 +1,67 @@
+package commands
+
+import "testing"
+
+func TestParseDDLColumnComments(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   map[string]string
+	}{
+		{
+			name:   "mysql comment clause",
+			script: "CREATE TABLE pets (id INT PRIMARY KEY, name VARCHAR(40) NOT NULL COMMENT 'Pet''s name') COMMENT='pets';",
+			want:   map[string]string{"id": "", "name": "Pet's name"},
+		},
+		{
+			name:   "postgres comment on column",
+			script: "CREATE TABLE pets (id SERIAL PRIMARY KEY, name TEXT);\nCOMMENT ON COLUMN public.pets.name IS 'Pet name';",
+			want:   map[string]string{"id": "", "name": "Pet name"},
+		},
+		{
+			name: "line comments",
+			script: `CREATE TABLE pets ( -- pets of the shop
+  id INTEGER PRIMARY KEY, -- generated
+  -- a comment on its own line documents nothing
+  price NUMERIC(10,2) NOT NULL DEFAULT 0, -- price, taxes included
+  name TEXT NOT NULL -- it's the pet name
+); -- end of the pets`,
+			want: map[string]string{"id": "generated", "price": "price, taxes included", "name": "it's the pet name"},
+		},
+		{
+			name:   "line comment after the opening parenthesis",
+			script: "CREATE TABLE pets (id INTEGER PRIMARY KEY, -- generated\nname TEXT);",
+			want:   map[string]string{"id": "generated", "name": ""},
+		},
+		{
+			name:   "block comments",
+			script: "CREATE TABLE pets (id INTEGER PRIMARY KEY /* generated */, name TEXT /* -- */);",
+			want:   map[string]string{"id": "", "name": ""},
+		},
+		{
+			name:   "dashes in strings",
+			script: "CREATE TABLE pets (id INTEGER PRIMARY KEY, name TEXT DEFAULT '--none--');",
+			want:   map[string]string{"id": "", "name": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tables, err := ParseDDL(tt.script)
+			if err != nil {
+				t.Fatalf("ParseDDL() error = %v", err)
+			}
+			if len(tables) != 1 || len(tables[0].Columns) != len(tt.want) {
+				t.Fatalf("ParseDDL() = %+v, want a table with the columns %v", tables, tt.want)
+			}
+			for name, want := range tt.want {
+				column := tables[0].Column(name)
+				if column == nil {
+					t.Fatalf("column %s not found", name)
+				}
+				if column.Comment != want {
+					t.Errorf("column %s comment = %q, want %q", name, column.Comment, want)
+				}
+			}
+		})
+	}
+}
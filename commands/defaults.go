@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultFunction matches the defaults calling an SQL function, like "now()" or "gen_random_uuid()",
+// which are written to the database as they are.
+var defaultFunction = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*\(.*\)$`)
+
+// defaultKeywords are the SQL expressions time fields accept as defaults besides function calls.
+var defaultKeywords = []string{"CURRENT_TIMESTAMP", "CURRENT_DATE", "CURRENT_TIME", "LOCALTIMESTAMP", "LOCALTIME"}
+
+// defaultTimeLayouts are the layouts of the dates time fields accept as defaults.
+var defaultTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+var (
+	decimalLiteral = regexp.MustCompile(`^[-+]?[0-9]+(\.[0-9]+)?$`)
+	uuidLiteral    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// isDefaultExpression reports whether the default of a field of type goType is an SQL expression rather than
+// a value: a function call, or for time fields one of defaultKeywords like CURRENT_TIMESTAMP.
+func isDefaultExpression(goType, value string) bool {
+	if defaultFunction.MatchString(value) {
+		return true
+	}
+	if goType == "time.Time" {
+		for _, keyword := range defaultKeywords {
+			if strings.EqualFold(value, keyword) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateDefault checks the default of a field against its type, and normalizes the booleans.
+func validateDefault(field *ModelField) error {
+	value := field.Default
+	if value == "" {
+		return nil
+	}
+	invalid := func(expected string) error {
+		return fmt.Errorf("invalid default %q of %s, expected %s", value, field.Name, expected)
+	}
+	if strings.Contains(value, "`") {
+		return invalid("a value without backquotes")
+	}
+	if isDefaultExpression(field.Type, value) {
+		return nil
+	}
+
+	fieldType, _ := LookupFieldType(field.Type)
+	switch {
+	case field.Type == "bool":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalid("true or false")
+		}
+		field.Default = strconv.FormatBool(parsed)
+	case isIntegerType(field.Type), field.Type == "byte", field.Type == "rune":
+		bits := integerBits(field.Type)
+		var err error
+		if strings.HasPrefix(field.Type, "uint") || field.Type == "byte" {
+			_, err = strconv.ParseUint(value, 10, bits)
+		} else {
+			_, err = strconv.ParseInt(value, 10, bits)
+		}
+		if err != nil {
+			return invalid("a whole number fitting in " + field.Type)
+		}
+	case field.Type == "float32", field.Type == "float64":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return invalid("a number")
+		}
+	case field.Type == "string", field.Enum:
+		// GORM trims spaces and quotes around string defaults
+		if strings.TrimSpace(value) != value || strings.Trim(value, `'"`) != value {
+			return invalid("a value without surrounding spaces or quotes")
+		}
+	case field.Type == "time.Time":
+		for _, layout := range defaultTimeLayouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				return nil
+			}
+		}
+		return invalid("a date like 2006-01-02, a time like 2006-01-02 15:04:05, CURRENT_TIMESTAMP or a function call")
+	case field.Type == "decimal.Decimal":
+		if !decimalLiteral.MatchString(value) {
+			return invalid("a decimal number like 9.99")
+		}
+	case field.Type == "uuid.UUID":
+		if !uuidLiteral.MatchString(value) {
+			return invalid("a UUID or a function call like gen_random_uuid()")
+		}
+	case field.Type == "datatypes.JSON":
+		if !json.Valid([]byte(value)) {
+			return invalid("a JSON value")
+		}
+	case strings.HasPrefix(fieldType.SwaggerType, "array"):
+		if !strings.HasPrefix(value, "{") || !strings.HasSuffix(value, "}") {
+			return invalid("an array literal like {a,b}")
+		}
+	case field.Type == "[]byte":
+		return invalid("no default, []byte fields cannot have one")
+	}
+	return nil
+}
+
+// integerBits returns the size in bits of an integer type.
+func integerBits(goType string) int {
+	switch goType {
+	case "int8", "uint8", "byte":
+		return 8
+	case "int16", "uint16":
+		return 16
+	case "int32", "uint32", "rune":
+		return 32
+	}
+	return 64
+}
+
+// quotedDefault reports whether the defaults of a field are written in its tag as SQL string literals.
+// GORM binds the defaults of strings, numbers and times itself, except the ones with parentheses, which
+// it writes to the database as they are.
+func quotedDefault(field ModelField) bool {
+	if field.Type == "string" || field.Enum {
+		return strings.Contains(field.Default, "(") && strings.Contains(field.Default, ")")
+	}
+	fieldType, _ := LookupFieldType(field.Type)
+	return field.Type == "uuid.UUID" || field.Type == "datatypes.JSON" || strings.HasPrefix(fieldType.SwaggerType, "array")
+}
+
+// gormDefault returns the default of a field as written in the "default" setting of its gorm tag:
+// quoted when it is a literal GORM does not bind, with its semicolons escaped. Function calls are
+// parenthesized, as SQLite only accepts them this way.
+func gormDefault(field ModelField) string {
+	value := field.Default
+	switch {
+	case defaultFunction.MatchString(value):
+		value = "(" + value + ")"
+	case !isDefaultExpression(field.Type, value) && quotedDefault(field):
+		value = sqlQuote(value)
+	}
+	return strings.ReplaceAll(value, ";", `\;`)
+}
+
+// modelDefault returns the default of a field as given to the generator from the "default" setting of its
+// gorm tag, the reverse of gormDefault.
+func modelDefault(field ModelField, setting string) string {
+	value := strings.TrimSpace(setting)
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") && defaultFunction.MatchString(value[1:len(value)-1]) {
+		return value[1 : len(value)-1]
+	}
+	if isDefaultExpression(field.Type, value) {
+		return value
+	}
+	quoted := len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")
+	if field.Type == "string" || field.Enum {
+		// Strings without parentheses are bound by GORM, which trims their quotes
+		if !strings.Contains(value, "(") || !strings.Contains(value, ")") {
+			return strings.Trim(value, `'"`)
+		}
+		if !quoted {
+			return value
+		}
+	} else if !quoted || !quotedDefault(field) {
+		return value
+	}
+	return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+}
+
+// columnDefault returns the default a column gets from the "default" setting of its gorm tag, as GORM
+// reads it: strings without parentheses are values with their quotes trimmed, the other defaults SQL.
+func columnDefault(goType, setting string) string {
+	value := strings.TrimSpace(setting)
+	if goType == "string" && !(strings.Contains(value, "(") && strings.Contains(value, ")")) {
+		return strings.Trim(value, `'"`)
+	}
+	return value
+}
+
+// sqlQuote returns value as an SQL string literal.
+func sqlQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// fieldExample returns the example of a field shown in Swagger: the one it is given, or else its default
+// unless it is an expression. Times are shown the way they are sent in JSON, and JSON documents and arrays
+// have none, as Swagger reads their examples in another format.
+func fieldExample(field ModelField) string {
+	fieldType, _ := LookupFieldType(field.Type)
+	if field.Type == "datatypes.JSON" || strings.HasPrefix(fieldType.SwaggerType, "array") {
+		return ""
+	}
+	example := field.Example
+	if example == "" && !isDefaultExpression(field.Type, field.Default) {
+		example = field.Default
+	}
+	if field.Type == "time.Time" {
+		return jsonTime(example)
+	}
+	return example
+}
+
+// jsonTime returns a time accepted as a default in RFC 3339, the format of times in JSON.
+func jsonTime(value string) string {
+	for _, layout := range defaultTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.Format(time.RFC3339)
+		}
+	}
+	return value
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateDefault(t *testing.T) {
+	tests := []struct {
+		goType  string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{goType: "bool", value: "TRUE", want: "true"},
+		{goType: "bool", value: "0", want: "false"},
+		{goType: "bool", value: "yes", wantErr: true},
+		{goType: "int", value: "-42", want: "-42"},
+		{goType: "int8", value: "128", wantErr: true},
+		{goType: "uint", value: "-1", wantErr: true},
+		{goType: "byte", value: "255", want: "255"},
+		{goType: "float64", value: "9.99", want: "9.99"},
+		{goType: "float32", value: "cheap", wantErr: true},
+		{goType: "string", value: "draft", want: "draft"},
+		{goType: "string", value: "it's", want: "it's"},
+		{goType: "string", value: " padded", wantErr: true},
+		{goType: "string", value: "'quoted'", wantErr: true},
+		{goType: "string", value: "a `tag`", wantErr: true},
+		{goType: "string", value: "gen_random_uuid()", want: "gen_random_uuid()"},
+		{goType: "time.Time", value: "CURRENT_TIMESTAMP", want: "CURRENT_TIMESTAMP"},
+		{goType: "time.Time", value: "now()", want: "now()"},
+		{goType: "time.Time", value: "2006-01-02", want: "2006-01-02"},
+		{goType: "time.Time", value: "2006-01-02 15:04:05", want: "2006-01-02 15:04:05"},
+		{goType: "time.Time", value: "yesterday", wantErr: true},
+		{goType: "decimal.Decimal", value: "-9.99", want: "-9.99"},
+		{goType: "decimal.Decimal", value: "9.", wantErr: true},
+		{goType: "uuid.UUID", value: "123e4567-e89b-12d3-a456-426614174000", want: "123e4567-e89b-12d3-a456-426614174000"},
+		{goType: "uuid.UUID", value: "123", wantErr: true},
+		{goType: "datatypes.JSON", value: `{"a": 1}`, want: `{"a": 1}`},
+		{goType: "datatypes.JSON", value: "{a}", wantErr: true},
+		{goType: "pq.StringArray", value: "{a,b}", want: "{a,b}"},
+		{goType: "pq.StringArray", value: "a,b", wantErr: true},
+		{goType: "[]byte", value: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goType+" "+tt.value, func(t *testing.T) {
+			field := ModelField{Name: "Value", Type: tt.goType, Default: tt.value}
+			err := validateDefault(&field)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateDefault() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && field.Default != tt.want {
+				t.Errorf("validateDefault() default = %q, want %q", field.Default, tt.want)
+			}
+		})
+	}
+}
+
+func TestGormDefault(t *testing.T) {
+	tests := []struct {
+		field ModelField
+		want  string
+	}{
+		{ModelField{Type: "string", Default: "draft"}, "draft"},
+		{ModelField{Type: "string", Default: "a;b"}, `a\;b`},
+		{ModelField{Type: "string", Default: "(draft)"}, "'(draft)'"},
+		{ModelField{Type: "string", Default: "it's (new)"}, "'it''s (new)'"},
+		{ModelField{Type: "string", Default: "upper(name)"}, "(upper(name))"},
+		{ModelField{Type: "int", Default: "42"}, "42"},
+		{ModelField{Type: "bool", Default: "true"}, "true"},
+		{ModelField{Type: "time.Time", Default: "CURRENT_TIMESTAMP"}, "CURRENT_TIMESTAMP"},
+		{ModelField{Type: "time.Time", Default: "now()"}, "(now())"},
+		{ModelField{Type: "uuid.UUID", Default: "123e4567-e89b-12d3-a456-426614174000"}, "'123e4567-e89b-12d3-a456-426614174000'"},
+		{ModelField{Type: "uuid.UUID", Default: "gen_random_uuid()"}, "(gen_random_uuid())"},
+		{ModelField{Type: "datatypes.JSON", Default: `{"a": 1}`}, `'{"a": 1}'`},
+		{ModelField{Type: "pq.StringArray", Default: "{a,b}"}, "'{a,b}'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field.Type+" "+tt.field.Default, func(t *testing.T) {
+			if got := gormDefault(tt.field); got != tt.want {
+				t.Errorf("gormDefault() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDefaultRoundTrip checks that the defaults written in the gorm tags are read back unchanged by
+// scaffold and sync. Tags are parsed with their semicolons unescaped.
+func TestDefaultRoundTrip(t *testing.T) {
+	tests := []ModelField{
+		{Type: "string", Default: "draft"},
+		{Type: "string", Default: "a;b"},
+		{Type: "string", Default: "(draft)"},
+		{Type: "string", Default: "it's (new)"},
+		{Type: "string", Default: "upper(name)"},
+		{Type: "string", Default: "draft", Enum: true},
+		{Type: "int", Default: "-42"},
+		{Type: "float64", Default: "9.99"},
+		{Type: "bool", Default: "false"},
+		{Type: "time.Time", Default: "CURRENT_TIMESTAMP"},
+		{Type: "time.Time", Default: "now()"},
+		{Type: "time.Time", Default: "2006-01-02"},
+		{Type: "decimal.Decimal", Default: "9.99"},
+		{Type: "uuid.UUID", Default: "123e4567-e89b-12d3-a456-426614174000"},
+		{Type: "uuid.UUID", Default: "gen_random_uuid()"},
+		{Type: "datatypes.JSON", Default: `{"name": "it's"}`},
+		{Type: "pq.StringArray", Default: "{a,b}"},
+	}
+	for _, field := range tests {
+		t.Run(field.Type+" "+field.Default, func(t *testing.T) {
+			field.Name = "Value"
+			if err := validateDefault(&field); err != nil {
+				t.Fatalf("validateDefault() error = %v", err)
+			}
+			setting := strings.ReplaceAll(gormDefault(field), `\;`, ";")
+			if got := modelDefault(field, setting); got != field.Default {
+				t.Errorf("modelDefault(%q) = %q, want %q", setting, got, field.Default)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// fieldDoc returns the doc comment of a struct field holding its description, which swag reads as the
+// description of the property, or an empty string.
+func fieldDoc(field ModelField) string {
+	if field.Description == "" {
+		return ""
+	}
+	return fmt.Sprintf("\t// %s\n", field.Description)
+}
+
+// structFieldDescription returns the description of a struct field: its doc comment, or else the
+// comment setting of its gorm tag.
+func structFieldDescription(field *ast.Field, tag map[string]string) string {
+	if field.Doc != nil {
+		if text := singleLine(field.Doc.Text()); text != "" {
+			return text
+		}
+	}
+	return tag["comment"]
+}
+
+// singleLine returns a description read from a schema on a single line, without backquotes.
+func singleLine(description string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(description, "`", "'")), " ")
+}
+
+// validateDescription checks that a description fits on the line of a doc comment and in a struct tag.
+func validateDescription(field ModelField) error {
+	if strings.ContainsAny(field.Description, "`\n\r") {
+		return fmt.Errorf("invalid description of %s, it has to be a single line without backquotes", field.Name)
+	}
+	return nil
+}
+
+// validateExample checks the example of a field against its type, its enum values and its maximum length,
+// as it is sent in the requests of the generated handler tests.
+func validateExample(field *ModelField) error {
+	if field.Example == "" {
+		return nil
+	}
+	// The checks of defaults apply, without the SQL expressions
+	check := *field
+	check.Default = field.Example
+	if err := validateDefault(&check); err != nil || isDefaultExpression(field.Type, field.Example) {
+		return fmt.Errorf("invalid example %q of %s, expected a value of type %s", field.Example, field.Name, field.Type)
+	}
+	field.Example = check.Default
+	if field.Type == "time.Time" {
+		field.Example = jsonTime(field.Example)
+	}
+	if len(field.Rules.Enum) > 0 && !slices.Contains(field.Rules.Enum, field.Example) {
+		return fmt.Errorf("invalid example %s of %s, expected one of %s", field.Example, field.Name, strings.Join(field.Rules.Enum, ", "))
+	}
+	if field.Rules.MaxLength != nil && utf8.RuneCountInString(field.Example) > *field.Rules.MaxLength {
+		return fmt.Errorf("the example of %s is longer than its maximum length %d", field.Name, *field.Rules.MaxLength)
+	}
+	return nil
+}
+
+// documentModelField writes the description of a field added to a model struct as its doc comment.
+// The comments of the edited struct are kept by position, which new fields do not have, so the line is
+// added to the formatted source.
+func documentModelField(structName string, field ModelField) error {
+	if field.Description == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(modelsDomainPath, "*.go"))
+	if err != nil {
+		return fmt.Errorf("error listing model files: %v", err)
+	}
+
+	structPattern := regexp.MustCompile(`(?m)^type ` + structName + ` struct \{$`)
+	fieldPattern := regexp.MustCompile(`(?m)^\t` + field.Name + `\s`)
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", path, err)
+		}
+		start := structPattern.FindIndex(content)
+		if start == nil {
+			continue
+		}
+		position := fieldPattern.FindIndex(content[start[1]:])
+		if position == nil {
+			return fmt.Errorf("field %s not found in %s", field.Name, path)
+		}
+
+		offset := start[1] + position[0]
+		documented := append([]byte{}, content[:offset]...)
+		documented = append(documented, fieldDoc(field)...)
+		documented = append(documented, content[offset:]...)
+		formatted, err := format.Source(documented)
+		if err != nil {
+			return fmt.Errorf("error formatting %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, formatted, 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", path, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("model %s not found", structName)
+}
@@ -0,0 +1,279 @@
+package commands
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// DestroyModel deletes every file generated for a model and removes the wiring injected for it,
+// leaving a compiling project. Models other models have relationships with are not destroyed.
+func DestroyModel(modelName string, migrate bool) error {
+	structName := utils.ToPascalCase(modelName)
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+	if _, err := FindModel(models, structName); err != nil {
+		return err
+	}
+
+	structs := modelStructs(models)
+	var references []string
+	for _, other := range models {
+		if other.Name == structName {
+			continue
+		}
+		for _, relation := range modelRelations(other, structs) {
+			if relation.Model == structName {
+				references = append(references, other.Name+"."+relation.Field)
+			}
+		}
+	}
+	if len(references) > 0 {
+		return fmt.Errorf("model %s is referenced by %s, remove those fields first", structName, strings.Join(references, ", "))
+	}
+
+	modelFilePath, err := modelFile(structName)
+	if err != nil {
+		return err
+	}
+
+	wiredName := wiredModelName(structName)
+	paths := append([]string{modelFilePath}, modelLayerPaths(structName, wiredName)...)
+
+	var removed []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("error removing %s: %v", path, err)
+		}
+		removed = append(removed, path)
+	}
+
+	if err := unwireModel(structName, wiredName); err != nil {
+		return err
+	}
+
+	// The seed runner lists the remaining factories, and goes away with the last one
+	if _, err := os.Stat(seedRunnerPath); err == nil {
+		factories, _ := filepath.Glob(filepath.Join(factoryDir, "*Factory.go"))
+		if len(factories) == 0 {
+			if err := os.Remove(seedRunnerPath); err != nil {
+				return fmt.Errorf("error removing %s: %v", seedRunnerPath, err)
+			}
+			removed = append(removed, seedRunnerPath)
+		} else if err := writeSeedRunner(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("Files removed:")
+	for _, path := range removed {
+		fmt.Printf("- %s\n", path)
+	}
+
+	if !migrate {
+		return nil
+	}
+	return GenerateMigration("drop_" + utils.ToSnakeCase(structName))
+}
+
+// modelFile returns the file declaring a model struct. The file is removed with the model,
+// so it must not declare other types than the enums of the model.
+func modelFile(structName string) (string, error) {
+	path, err := structFile(structName)
+	if err != nil {
+		return "", err
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if typeSpec.Name.Name == structName {
+				continue
+			}
+			// Enums are named after their model, like PostStatus
+			if _, isStruct := typeSpec.Type.(*ast.StructType); !isStruct && strings.HasPrefix(typeSpec.Name.Name, structName) {
+				continue
+			}
+			return "", fmt.Errorf("%s declares other types than %s, move them to their own file first", path, structName)
+		}
+	}
+	return path, nil
+}
+
+// structFile returns the file declaring a model struct.
+func structFile(structName string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(modelsDomainPath, "*.go"))
+	if err != nil {
+		return "", fmt.Errorf("error listing model files: %v", err)
+	}
+
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return "", fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		for _, decl := range file.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
+				for _, spec := range genDecl.Specs {
+					if spec.(*ast.TypeSpec).Name.Name == structName {
+						return path, nil
+					}
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("model %s not found", structName)
+}
+
+// modelLayerPaths returns the files and directories generated for a model, besides its domain model file.
+// Directories, handlers and mocks are named after the model name given on creation, the other files
+// after its camelCase version.
+func modelLayerPaths(structName, wiredName string) []string {
+	fileName := utils.ToCamelCase(wiredName)
+	return []string{
+		filepath.Join("internal", "app", "transport", "inbound", fileName+".go"),
+		filepath.Join("internal", "app", "transport", "inbound", fileName+"Validator.go"),
+		filepath.Join("internal", "app", "transport", "mapper", fileName+"MapToModel.go"),
+		filepath.Join("internal", "app", "transport", "outbound", fileName+".go"),
+		filepath.Join("internal", "app", "transport", "mapper", fileName+"MapToOutbound.go"),
+		filepath.Join("internal", "app", "domain", "repository", wiredName),
+		filepath.Join("internal", "app", "domain", "service", wiredName),
+		filepath.Join("internal", "app", "domain", "mocks", wiredName+"RepositoryMock.go"),
+		filepath.Join("internal", "app", "domain", "mocks", wiredName+"ServiceMock.go"),
+		filepath.Join("internal", "app", "adapter", "handler", wiredName+"Handler.go"),
+		filepath.Join("internal", "app", "adapter", "handler", wiredName+"Handler_test.go"),
+		filepath.Join(factoryDir, utils.ToCamelCase(structName)+"Factory.go"),
+	}
+}
+
+// wiredModelName returns the model name services.go was wired with, like "product" in
+// `ProductService productService.ProductService`, or the camelCase struct name when it is not wired.
+func wiredModelName(structName string) string {
+	content, err := os.ReadFile(filepath.Join("internal", "app", "domain", "services.go"))
+	if err == nil {
+		field := regexp.MustCompile(`(?m)^\s*` + structName + `Service\s+(\w+)Service\.` + structName + `Service\s*$`)
+		if match := field.FindStringSubmatch(string(content)); match != nil {
+			return match[1]
+		}
+	}
+	return utils.ToCamelCase(structName)
+}
+
+// unwireModel removes the lines editServicesFile and updateHandlersFile injected for a model,
+// and the model from the AutoMigrate call of runMigrations.
+func unwireModel(structName, wiredName string) error {
+	servicesFile := filepath.Join("internal", "app", "domain", "services.go")
+	err := removeLines(servicesFile, []*regexp.Regexp{
+		regexp.MustCompile(`^\s*` + wiredName + `(Repository|Service)\s+"[^"]+"$`),
+		regexp.MustCompile(`^\s*` + structName + `Service\s+` + wiredName + `Service\.` + structName + `Service$`),
+		regexp.MustCompile(`^\s*` + structName + `Service:\s+` + wiredName + `Service\.New` + structName + `Service\(.*\),$`),
+	})
+	if err != nil {
+		return err
+	}
+
+	handlersFile := filepath.Join("internal", "app", "adapter", "handlers.go")
+	err = removeLines(handlersFile, []*regexp.Regexp{
+		regexp.MustCompile(`^\s*` + wiredName + `Handler\s+\*handler\.` + structName + `Handler$`),
+		regexp.MustCompile(`^\s*` + wiredName + `Handler:\s+handler\.New` + structName + `Handler\(services\),$`),
+		regexp.MustCompile(`^\s*h\.` + wiredName + `Handler\.Configure\(server\)$`),
+	})
+	if err != nil {
+		return err
+	}
+	if err := removeUnusedImport(handlersFile, "handler"); err != nil {
+		return err
+	}
+
+	databasesFile := filepath.Join("internal", "infra", "database", "databases.go")
+	content, err := os.ReadFile(databasesFile)
+	if err != nil {
+		return nil
+	}
+	entry := regexp.MustCompile(`&model\.` + structName + `\{\}\s*,?\s*`)
+	updated := entry.ReplaceAllString(string(content), "")
+	if updated == string(content) {
+		return nil
+	}
+	if err := writeFormatted(databasesFile, updated); err != nil {
+		return err
+	}
+	return removeUnusedImport(databasesFile, "model")
+}
+
+// removeLines removes the lines of a file matching any of the patterns. A missing file is left alone.
+func removeLines(path string, patterns []*regexp.Regexp) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		matched := false
+		for _, pattern := range patterns {
+			if pattern.MatchString(line) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			lines = append(lines, line)
+		}
+	}
+	return writeFormatted(path, strings.Join(lines, "\n"))
+}
+
+// writeFormatted writes Go source to path, formatted when it parses, so the
+// emptied blocks read like `&Services{}` again.
+func writeFormatted(path, content string) error {
+	content = strings.ReplaceAll(content, "import (\n\n", "import (\n")
+	if formatted, err := format.Source([]byte(content)); err == nil {
+		content = string(formatted)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
+
+// removeUnusedImport removes the import of the package named name once the file no longer uses it.
+func removeUnusedImport(path, name string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	if regexp.MustCompile(`\b` + name + `\.`).Match(removeImportBlock(content)) {
+		return nil
+	}
+	return writeFormatted(path, removeImportLine(string(content), name))
+}
+
+// removeImportBlock returns the content of a Go file without its import declarations.
+func removeImportBlock(content []byte) []byte {
+	return regexp.MustCompile(`(?s)import \(.*?\)|import "[^"]*"`).ReplaceAll(content, nil)
+}
@@ -0,0 +1,269 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect renders the SQL statements of a migration for one database.
+type Dialect interface {
+	// Name returns the dialect name as used in the project configuration.
+	Name() string
+	// Quote quotes a table or column identifier.
+	Quote(identifier string) string
+	// ColumnType returns the SQL type of a column.
+	ColumnType(column Column) (string, error)
+	// AlterColumn returns the statements that change a column from old to new.
+	AlterColumn(table string, old, new Column) ([]string, error)
+}
+
+// Dialects lists the supported SQL dialects by name.
+var Dialects = map[string]Dialect{
+	"postgres": postgresDialect{},
+	"mysql":    mysqlDialect{},
+	"sqlite":   sqliteDialect{},
+}
+
+// dialectDrivers maps each dialect to the GORM driver package the generated programs open it with.
+var dialectDrivers = map[string]string{
+	"postgres": "gorm.io/driver/postgres",
+	"mysql":    "gorm.io/driver/mysql",
+	"sqlite":   "gorm.io/driver/sqlite",
+}
+
+// GetDialect returns the dialect registered under name.
+func GetDialect(name string) (Dialect, error) {
+	dialect, ok := Dialects[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dialect %q, supported dialects are: postgres, mysql, sqlite", name)
+	}
+	return dialect, nil
+}
+
+// columnDefinition renders a column as used in CREATE TABLE and ADD COLUMN statements.
+func columnDefinition(dialect Dialect, column Column) (string, error) {
+	sqlType, err := dialect.ColumnType(column)
+	if err != nil {
+		return "", err
+	}
+
+	definition := dialect.Quote(column.Name) + " " + sqlType
+	if column.PrimaryKey {
+		// SQLite only auto increments an INTEGER PRIMARY KEY declared inline
+		if _, ok := dialect.(sqliteDialect); ok && column.AutoIncrement {
+			return definition + " PRIMARY KEY AUTOINCREMENT", nil
+		}
+		return definition + " PRIMARY KEY", nil
+	}
+	if column.NotNull {
+		definition += " NOT NULL"
+	}
+	if column.Default != "" {
+		definition += " DEFAULT " + sqlDefault(column)
+	}
+	// MySQL declares comments with the column, PostgreSQL sets them with commentStatements
+	if _, ok := dialect.(mysqlDialect); ok && column.Comment != "" {
+		definition += " COMMENT " + sqlQuote(column.Comment)
+	}
+	return definition, nil
+}
+
+// sqlDefault renders the default value of a column.
+// String and time defaults are quoted unless they already are or are expressions.
+func sqlDefault(column Column) string {
+	value := column.Default
+	literal := column.GoType == "string" || (column.GoType == "time.Time" && !isDefaultExpression(column.GoType, value))
+	if !literal || strings.HasPrefix(value, "'") || strings.Contains(value, "(") || strings.EqualFold(value, "null") {
+		return value
+	}
+	return sqlQuote(value)
+}
+
+// registeredColumnType returns the SQL type set by the gorm tag of a column, or else the one registered
+// for its Go type in the dialect, or an empty string.
+func registeredColumnType(dialect Dialect, column Column) string {
+	if column.SQLType != "" {
+		return column.SQLType
+	}
+	fieldType, _ := LookupFieldType(column.GoType)
+	return fieldType.SQLTypes[dialect.Name()]
+}
+
+// baseColumnType maps the Go types the generator emits to a portable SQL type family.
+func baseColumnType(goType string) (string, error) {
+	switch goType {
+	case "bool":
+		return "bool", nil
+	case "int8", "uint8", "byte", "int16", "uint16":
+		return "smallint", nil
+	case "int32", "uint32", "rune":
+		return "integer", nil
+	case "int", "uint", "int64", "uint64":
+		return "bigint", nil
+	case "float32":
+		return "real", nil
+	case "float64":
+		return "double", nil
+	case "string":
+		return "text", nil
+	case "time.Time", "gorm.DeletedAt":
+		return "timestamp", nil
+	case "[]byte":
+		return "blob", nil
+	}
+	return "", fmt.Errorf("type %s has no SQL mapping, set it with a gorm:\"type:...\" tag", goType)
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return "postgres" }
+
+func (postgresDialect) Quote(identifier string) string { return `"` + identifier + `"` }
+
+func (d postgresDialect) ColumnType(column Column) (string, error) {
+	if sqlType := registeredColumnType(d, column); sqlType != "" {
+		return sqlType, nil
+	}
+	base, err := baseColumnType(column.GoType)
+	if err != nil {
+		return "", err
+	}
+	if column.AutoIncrement {
+		if base == "bigint" {
+			return "bigserial", nil
+		}
+		return "serial", nil
+	}
+	switch base {
+	case "bool":
+		return "boolean", nil
+	case "double":
+		return "double precision", nil
+	case "text":
+		if column.Size > 0 {
+			return fmt.Sprintf("varchar(%d)", column.Size), nil
+		}
+	case "timestamp":
+		return "timestamptz", nil
+	case "blob":
+		return "bytea", nil
+	}
+	return base, nil
+}
+
+func (d postgresDialect) AlterColumn(table string, old, new Column) ([]string, error) {
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", d.Quote(table), d.Quote(new.Name))
+
+	var statements []string
+	oldType, err := d.ColumnType(old)
+	if err != nil {
+		return nil, err
+	}
+	newType, err := d.ColumnType(new)
+	if err != nil {
+		return nil, err
+	}
+	if oldType != newType {
+		statements = append(statements, fmt.Sprintf("%s TYPE %s USING %s::%s;", prefix, newType, d.Quote(new.Name), newType))
+	}
+	if old.NotNull != new.NotNull {
+		if new.NotNull {
+			statements = append(statements, prefix+" SET NOT NULL;")
+		} else {
+			statements = append(statements, prefix+" DROP NOT NULL;")
+		}
+	}
+	if old.Default != new.Default {
+		if new.Default == "" {
+			statements = append(statements, prefix+" DROP DEFAULT;")
+		} else {
+			statements = append(statements, prefix+" SET DEFAULT "+sqlDefault(new)+";")
+		}
+	}
+	return statements, nil
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string { return "mysql" }
+
+func (mysqlDialect) Quote(identifier string) string { return "`" + identifier + "`" }
+
+func (d mysqlDialect) ColumnType(column Column) (string, error) {
+	if sqlType := registeredColumnType(d, column); sqlType != "" {
+		return sqlType, nil
+	}
+	base, err := baseColumnType(column.GoType)
+	if err != nil {
+		return "", err
+	}
+
+	sqlType := base
+	switch base {
+	case "bool":
+		sqlType = "boolean"
+	case "smallint", "integer", "bigint":
+		if strings.HasPrefix(column.GoType, "uint") || column.GoType == "byte" {
+			sqlType += " unsigned"
+		}
+	case "double":
+		sqlType = "double"
+	case "text":
+		// Indexed strings need a bounded length in MySQL
+		if column.Size > 0 {
+			sqlType = fmt.Sprintf("varchar(%d)", column.Size)
+		} else if column.Index || column.Unique || column.PrimaryKey {
+			sqlType = "varchar(191)"
+		} else {
+			sqlType = "longtext"
+		}
+	case "timestamp":
+		sqlType = "datetime(3)"
+	case "blob":
+		sqlType = "longblob"
+	}
+	if column.AutoIncrement {
+		sqlType += " AUTO_INCREMENT"
+	}
+	return sqlType, nil
+}
+
+func (d mysqlDialect) AlterColumn(table string, old, new Column) ([]string, error) {
+	definition, err := columnDefinition(d, new)
+	if err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", d.Quote(table), definition)}, nil
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+func (sqliteDialect) Quote(identifier string) string { return `"` + identifier + `"` }
+
+func (d sqliteDialect) ColumnType(column Column) (string, error) {
+	if sqlType := registeredColumnType(d, column); sqlType != "" {
+		return sqlType, nil
+	}
+	base, err := baseColumnType(column.GoType)
+	if err != nil {
+		return "", err
+	}
+	switch base {
+	case "bool":
+		return "numeric", nil
+	case "smallint", "bigint":
+		return "integer", nil
+	case "double":
+		return "real", nil
+	case "timestamp":
+		return "datetime", nil
+	}
+	return base, nil
+}
+
+func (d sqliteDialect) AlterColumn(table string, old, new Column) ([]string, error) {
+	// SQLite can't alter a column in place, the table has to be rebuilt by hand
+	return []string{fmt.Sprintf("-- SQLite cannot alter column %s of table %s in place, rebuild the table to apply this change.", d.Quote(new.Name), d.Quote(table))}, nil
+}
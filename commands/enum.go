@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+)
+
+// enumDefinition matches the type of enum fields in field definitions, like "enum(draft,published)".
+var enumDefinition = regexp.MustCompile(`^enum\((.*)\)$`)
+
+// enumValue matches the values enums accept. They end up in constant names, SQL literals and struct tags.
+var enumValue = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// parseEnumValues parses the comma-separated values of an enum.
+func parseEnumValues(list string) ([]string, error) {
+	var values []string
+	seen := map[string]bool{}
+	for _, value := range strings.Split(list, ",") {
+		value = strings.TrimSpace(value)
+		if !enumValue.MatchString(value) {
+			return nil, fmt.Errorf("invalid enum value %q, use letters, digits, dots, dashes and underscores", value)
+		}
+		if seen[inflection.Pascalize(value)] {
+			return nil, fmt.Errorf("duplicate enum value %q", value)
+		}
+		seen[inflection.Pascalize(value)] = true
+		values = append(values, value)
+	}
+	if len(values) < 2 {
+		return nil, fmt.Errorf("an enum needs at least two values")
+	}
+	return values, nil
+}
+
+// enumField turns a field into an enum of a model: its type is named after the model and the field,
+// like PostStatus, and it defaults to the first value unless it is nullable or has a default.
+func enumField(structName string, field ModelField, values []string) (ModelField, error) {
+	if field.Default != "" && !slices.Contains(values, field.Default) {
+		return field, fmt.Errorf("invalid default %s of %s, expected one of %s", field.Default, field.Name, strings.Join(values, ", "))
+	}
+	field.Type = structName + field.Name
+	field.Enum = true
+	field.Rules.Enum = values
+	if !field.Nullable && field.Default == "" {
+		field.Default = values[0]
+	}
+	return field, nil
+}
+
+// enumCheck returns the check constraint of an enum column, set with the check setting of its gorm tag.
+func enumCheck(column string, values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "'" + value + "'"
+	}
+	// PostgreSQL folds the unquoted columns to lowercase
+	if projectDialect() == "postgres" && strings.ToLower(column) != column {
+		column = postgresDialect{}.Quote(column)
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(quoted, ","))
+}
+
+// enumDeclaration returns the named string type of an enum field, with a constant for each value,
+// a validity check and a JSON decoding rejecting the other values.
+func enumDeclaration(field ModelField) string {
+	typeName := field.Type
+	var constants, names []string
+	for _, value := range field.Rules.Enum {
+		name := typeName + inflection.Pascalize(value)
+		constants = append(constants, fmt.Sprintf("\t%s %s = %q", name, typeName, value))
+		names = append(names, name)
+	}
+
+	return fmt.Sprintf(`
+// %[1]s holds the %[2]s of a %[3]s.
+type %[1]s string
+
+const (
+%[4]s
+)
+
+// %[1]sValues lists the valid %[1]s values.
+var %[1]sValues = []%[1]s{%[5]s}
+
+// IsValid reports whether the value is one of the %[1]s values.
+func (s %[1]s) IsValid() bool {
+	return slices.Contains(%[1]sValues, s)
+}
+
+// UnmarshalJSON decodes a %[1]s, rejecting the values that are not valid.
+func (s *%[1]s) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if !%[1]s(value).IsValid() {
+		return fmt.Errorf("invalid %[2]s %%q, expected one of %[6]s", value)
+	}
+	*s = %[1]s(value)
+	return nil
+}
+`, typeName, toColumnName(field.Name), strings.TrimSuffix(typeName, field.Name), strings.Join(constants, "\n"), strings.Join(names, ", "), strings.Join(field.Rules.Enum, ", "))
+}
+
+// structEnumValues returns the values of a struct field typed with an enum of the model package:
+// a type declared next to the models whose values are listed in the rules tag.
+func structEnumValues(field *ast.Field) []string {
+	goType := strings.TrimPrefix(types.ExprString(field.Type), "*")
+	if !token.IsIdentifier(goType) || types.Universe.Lookup(goType) != nil || field.Tag == nil {
+		return nil
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return nil
+	}
+	return parseRulesTag(reflect.StructTag(tag).Get("rules")).Enum
+}
+
+// enumFakeValue returns the expression picking a random value of an enum in factories.
+func enumFakeValue(goType string, values []string) string {
+	value := fmt.Sprintf("model.%sValues[fake.Int(0, %d)]", strings.TrimPrefix(goType, "*"), len(values)-1)
+	if strings.HasPrefix(goType, "*") {
+		return fmt.Sprintf("fake.Ptr(%s)", value)
+	}
+	return value
+}
+
+// appendEnumDeclaration adds the type of an enum field to the model file declaring its struct.
+func appendEnumDeclaration(structName string, field ModelField) error {
+	path, err := structFile(structName)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+	return writeModelSource(path, string(content)+enumDeclaration(field))
+}
+
+// removeEnumDeclaration removes the type of an enum field from the model file declaring its struct:
+// the type, its constants, its values and its methods.
+func removeEnumDeclaration(structName, typeName string) error {
+	path, err := structFile(structName)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	declaresEnum := func(decl ast.Decl) bool {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			return decl.Recv != nil && strings.TrimPrefix(types.ExprString(decl.Recv.List[0].Type), "*") == typeName
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					return spec.Name.Name == typeName
+				case *ast.ValueSpec:
+					if spec.Type != nil && types.ExprString(spec.Type) == typeName {
+						return true
+					}
+					return len(spec.Names) == 1 && spec.Names[0].Name == typeName+"Values"
+				}
+			}
+		}
+		return false
+	}
+
+	// Declarations are cut from the end, so the offsets of the previous ones stay valid
+	for i := len(file.Decls) - 1; i >= 0; i-- {
+		decl := file.Decls[i]
+		if !declaresEnum(decl) {
+			continue
+		}
+		start := decl.Pos()
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+		case *ast.GenDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+		}
+		content = append(content[:fset.Position(start).Offset], content[fset.Position(decl.End()).Offset:]...)
+	}
+	return writeModelSource(path, string(content))
+}
+
+// writeModelSource writes a model file with the imports it uses, formatted.
+func writeModelSource(path, content string) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	syncImports(file)
+
+	var formatted strings.Builder
+	if err := format.Node(&formatted, fset, file); err != nil {
+		return fmt.Errorf("error formatting %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, groupImports([]byte(formatted.String())), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,776 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// Paths used by the factory and seed commands, relative to the project root.
+var (
+	factoryDir       = filepath.Join("internal", "app", "domain", "factory")
+	seedRunnerPath   = filepath.Join("cmd", "seed", "main.go")
+	modelsDomainPath = filepath.Join("internal", "app", "domain", "model")
+)
+
+// factoryRelation is a relationship field of a model, filled by the factory builders.
+type factoryRelation struct {
+	Field      string // Go field holding the related model(s)
+	Model      string // related model struct name
+	ForeignKey string // foreign key field on the model, empty when unknown
+	Pointer    bool   // the field holds pointers to the related model
+	Many       bool   // the field is a slice (has many)
+}
+
+// GenerateFactory generates the factory of a model and of every related model without one.
+// It also regenerates the seeder so the new factories can be used with `silveirinha seed`.
+func GenerateFactory(modelName string) error {
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(factoryDir, "fake"), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating factory directory: %v", err)
+	}
+
+	// The fake data helpers are shared by every factory
+	fakeFilePath := filepath.Join(factoryDir, "fake", "fake.go")
+	if _, err := os.Stat(fakeFilePath); os.IsNotExist(err) {
+		if err := writeFakeFile(fakeFilePath); err != nil {
+			return fmt.Errorf("error writing fake data file: %v", err)
+		}
+	}
+
+	if err := generateFactoryFile(models, utils.ToPascalCase(modelName), map[string]bool{}, true); err != nil {
+		return err
+	}
+
+	return writeSeedRunner()
+}
+
+// generateFactoryFile writes the factory of structName and recurses into its relationships.
+// Related models only get a factory when they don't have one yet.
+func generateFactoryFile(models []ModelStruct, structName string, visited map[string]bool, overwrite bool) error {
+	if visited[structName] {
+		return nil
+	}
+	visited[structName] = true
+
+	model, err := FindModel(models, structName)
+	if err != nil {
+		return err
+	}
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current working directory: %v", err)
+	}
+	currentFolderName := filepath.Base(currentDir)
+
+	filePath := filepath.Join(factoryDir, fmt.Sprintf("%sFactory.go", utils.ToCamelCase(structName)))
+	if _, err := os.Stat(filePath); err == nil && !overwrite {
+		return nil
+	}
+
+	relations := modelRelations(*model, modelStructs(models))
+	if err := writeFactoryFile(filePath, currentFolderName, *model, relations); err != nil {
+		return fmt.Errorf("error writing factory file: %v", err)
+	}
+	fmt.Printf("Factory generated at: %s\n", filePath)
+
+	for _, relation := range relations {
+		if err := generateFactoryFile(models, relation.Model, visited, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// modelRelations returns the relationship fields of a model.
+func modelRelations(model ModelStruct, structs map[string][]*ast.Field) []factoryRelation {
+	fieldNames := map[string]bool{}
+	for _, field := range model.Fields {
+		for _, name := range field.Names {
+			fieldNames[name.Name] = true
+		}
+	}
+
+	var relations []factoryRelation
+	for _, field := range model.Fields {
+		if len(field.Names) == 0 {
+			continue
+		}
+
+		goType := types.ExprString(field.Type)
+		relation := factoryRelation{Many: strings.HasPrefix(goType, "[]")}
+		baseType := strings.TrimPrefix(goType, "[]")
+		relation.Pointer = strings.HasPrefix(baseType, "*")
+		relation.Model = strings.TrimPrefix(baseType, "*")
+		if _, isModel := structs[relation.Model]; !isModel {
+			continue
+		}
+
+		for _, name := range field.Names {
+			relation.Field = name.Name
+			relation.ForeignKey = ""
+			if !relation.Many {
+				if foreignKey, ok := gormTag(field)["foreignKey"]; ok && fieldNames[foreignKey] {
+					relation.ForeignKey = foreignKey
+				} else if fieldNames[name.Name+"ID"] {
+					relation.ForeignKey = name.Name + "ID"
+				} else if fieldNames[name.Name+"Id"] {
+					relation.ForeignKey = name.Name + "Id"
+				}
+			}
+			relations = append(relations, relation)
+		}
+	}
+	return relations
+}
+
+// writeFactoryFile creates the factory of a model.
+func writeFactoryFile(filePath, currentFolderName string, model ModelStruct, relations []factoryRelation) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	structName := model.Name
+	varName := modelVariable(structName)
+
+	// Fields owned by the relationships are filled by the builders, not with fake data
+	skipped := map[string]bool{}
+	for _, relation := range relations {
+		skipped[relation.Field] = true
+		skipped[relation.ForeignKey] = true
+	}
+
+	var values strings.Builder
+	for _, field := range model.Fields {
+		if _, ignored := gormTag(field)["-"]; ignored {
+			continue
+		}
+		for _, name := range field.Names {
+			if skipped[name.Name] || !name.IsExported() {
+				continue
+			}
+			value := fakeValue(name.Name, types.ExprString(field.Type))
+			if enumValues := structEnumValues(field); len(enumValues) > 0 {
+				value = enumFakeValue(types.ExprString(field.Type), enumValues)
+			}
+			if value != "" {
+				values.WriteString(fmt.Sprintf("\t\t%s: %s,\n", name.Name, value))
+			}
+		}
+	}
+
+	var builders strings.Builder
+	for _, relation := range relations {
+		builders.WriteString(relationBuilders(structName, varName, relation))
+	}
+
+	// The fake package is only imported when a field uses it
+	fakeImport := ""
+	if strings.Contains(values.String(), "fake.") {
+		fakeImport = fmt.Sprintf("\t\"%s/internal/app/domain/factory/fake\"\n", currentFolderName)
+	}
+
+	// So are the packages of the registered types the fake data is built with
+	external := []string{"gorm.io/gorm"}
+	for _, fieldType := range fieldTypes {
+		if fieldType.Import != "" && strings.Contains(values.String(), fieldType.PackageName()+".") && !slices.Contains(external, fieldType.Import) {
+			external = append(external, fieldType.Import)
+		}
+	}
+	sort.Strings(external)
+	externalImports := ""
+	for _, pkg := range external {
+		externalImports += fmt.Sprintf("\t%q\n", pkg)
+	}
+
+	content := fmt.Sprintf(`package factory
+
+import (
+	"context"
+
+%[6]s	"%[1]s/internal/app/domain/model"
+
+%[8]s)
+
+// %[2]sFactory builds model.%[2]s values filled with realistic fake data.
+type %[2]sFactory struct {
+	overrides []func(*model.%[2]s)
+}
+
+// New%[2]sFactory returns a factory for model.%[2]s.
+func New%[2]sFactory() *%[2]sFactory {
+	return &%[2]sFactory{}
+}
+
+// With registers a function applied to every %[2]s built, after the fake data is set.
+func (f *%[2]sFactory) With(override func(*model.%[2]s)) *%[2]sFactory {
+	f.overrides = append(f.overrides, override)
+	return f
+}
+%[5]s
+// Make builds a single %[2]s.
+func (f *%[2]sFactory) Make() model.%[2]s {
+	%[3]s := model.%[2]s{
+%[4]s	}
+	for _, override := range f.overrides {
+		override(&%[3]s)
+	}
+	return %[3]s
+}
+
+// MakeMany builds count %[2]s values.
+func (f *%[2]sFactory) MakeMany(count int) []model.%[2]s {
+	%[7]s := make([]model.%[2]s, 0, count)
+	for i := 0; i < count; i++ {
+		%[7]s = append(%[7]s, f.Make())
+	}
+	return %[7]s
+}
+
+// Create builds a %[2]s and inserts it through db.
+func (f *%[2]sFactory) Create(ctx context.Context, db *gorm.DB) (*model.%[2]s, error) {
+	%[3]s := f.Make()
+	if err := db.WithContext(ctx).Create(&%[3]s).Error; err != nil {
+		return nil, err
+	}
+	return &%[3]s, nil
+}
+
+// CreateMany builds count %[2]s values and inserts them through db in batches.
+func (f *%[2]sFactory) CreateMany(ctx context.Context, db *gorm.DB, count int) ([]model.%[2]s, error) {
+	%[7]s := f.MakeMany(count)
+	if len(%[7]s) == 0 {
+		return %[7]s, nil
+	}
+	if err := db.WithContext(ctx).CreateInBatches(%[7]s, 100).Error; err != nil {
+		return nil, err
+	}
+	return %[7]s, nil
+}
+`, currentFolderName, structName, varName, values.String(), builders.String(), fakeImport, modelVariable(inflection.Pluralize(structName)), externalImports)
+
+	writer.WriteString(content)
+	writer.Flush()
+	return nil
+}
+
+// relationBuilders returns the factory methods that fill a relationship.
+// Belongs-to relations can point at an existing record or build a new one,
+// has-many relations build the requested number of related records.
+func relationBuilders(structName, varName string, relation factoryRelation) string {
+	relatedVar := modelVariable(relation.Model)
+	address := ""
+	if relation.Pointer {
+		address = "&"
+	}
+
+	if relation.Many {
+		assign := fmt.Sprintf("\t\t%s.%s = New%sFactory().MakeMany(count)\n", varName, relation.Field, relation.Model)
+		if relation.Pointer {
+			assign = fmt.Sprintf(`		for _, %[1]s := range New%[2]sFactory().MakeMany(count) {
+			%[1]s := %[1]s
+			%[3]s.%[4]s = append(%[3]s.%[4]s, &%[1]s)
+		}
+`, relatedVar, relation.Model, varName, relation.Field)
+		}
+		return fmt.Sprintf(`
+// With%[1]s builds count related %[2]s values with their factory.
+func (f *%[3]sFactory) With%[1]s(count int) *%[3]sFactory {
+	return f.With(func(%[4]s *model.%[3]s) {
+%[5]s	})
+}
+`, relation.Field, relation.Model, structName, varName, assign)
+	}
+
+	builders := ""
+	if relation.ForeignKey != "" {
+		builders = fmt.Sprintf(`
+// With%[1]s links the built %[2]s values to an existing %[3]s.
+func (f *%[2]sFactory) With%[1]s(%[4]s *model.%[3]s) *%[2]sFactory {
+	return f.With(func(%[5]s *model.%[2]s) {
+		%[5]s.%[6]s = %[4]s.ID
+	})
+}
+`, relation.Field, structName, relation.Model, relatedVar, varName, relation.ForeignKey)
+	}
+
+	return builders + fmt.Sprintf(`
+// WithNew%[1]s builds a new %[3]s with its factory, inserted together with the %[2]s.
+func (f *%[2]sFactory) WithNew%[1]s() *%[2]sFactory {
+	return f.With(func(%[4]s *model.%[2]s) {
+		%[5]s := New%[3]sFactory().Make()
+		%[4]s.%[1]s = %[6]s%[5]s
+	})
+}
+`, relation.Field, structName, relation.Model, varName, relatedVar, address)
+}
+
+// fakeValue returns the Go expression generating fake data for a field, guessed from its
+// name and type. Server-managed fields and unsupported types return an empty string.
+func fakeValue(fieldName, goType string) string {
+	switch fieldName {
+	case "ID", "CreatedAt", "UpdatedAt", "DeletedAt":
+		return ""
+	}
+
+	pointer := strings.HasPrefix(goType, "*")
+	baseType := strings.TrimPrefix(goType, "*")
+	name := strings.ToLower(fieldName)
+
+	value := basicFakeValue(name, baseType)
+	if fieldType, ok := LookupFieldType(baseType); ok && fieldType.Fake != "" {
+		value = fieldType.Fake
+	}
+	if value == "" {
+		return ""
+	}
+
+	if pointer {
+		return fmt.Sprintf("fake.Ptr(%s)", value)
+	}
+	return value
+}
+
+// basicFakeValue returns the fake data expression of a basic Go type, or an empty string.
+func basicFakeValue(name, baseType string) string {
+	switch baseType {
+	case "string":
+		return fakeString(name)
+	case "int":
+		return fakeInt(name)
+	case "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return fmt.Sprintf("%s(%s)", baseType, fakeInt(name))
+	case "float64":
+		return fakeFloat(name)
+	case "float32":
+		return fmt.Sprintf("float32(%s)", fakeFloat(name))
+	case "bool":
+		return "fake.Bool()"
+	case "time.Time":
+		return fakeTime(name)
+	case "[]byte":
+		return "[]byte(fake.Word())"
+	}
+	return ""
+}
+
+// fakeString picks the fake string generator matching a lower-cased field name.
+func fakeString(name string) string {
+	generators := []struct {
+		keywords  []string
+		generator string
+	}{
+		{[]string{"email", "mail"}, "fake.Email()"},
+		{[]string{"firstname", "givenname"}, "fake.FirstName()"},
+		{[]string{"lastname", "surname", "familyname"}, "fake.LastName()"},
+		{[]string{"username", "login", "nickname", "handle"}, "fake.Username()"},
+		{[]string{"company", "organization"}, "fake.Company()"},
+		{[]string{"name"}, "fake.Name()"},
+		{[]string{"password", "secret", "token"}, "fake.Password()"},
+		{[]string{"phone", "mobile", "cellphone"}, "fake.Phone()"},
+		{[]string{"url", "website", "link", "avatar", "image", "photo"}, "fake.URL()"},
+		{[]string{"street", "address"}, "fake.Street()"},
+		{[]string{"city", "town"}, "fake.City()"},
+		{[]string{"country"}, "fake.Country()"},
+		{[]string{"zip", "postal"}, "fake.ZipCode()"},
+		{[]string{"slug"}, "fake.Slug()"},
+		{[]string{"title", "subject", "headline"}, "fake.Sentence()"},
+		{[]string{"description", "content", "body", "bio", "text", "comment", "note", "summary", "message"}, "fake.Paragraph()"},
+	}
+
+	for _, candidate := range generators {
+		for _, keyword := range candidate.keywords {
+			if strings.Contains(name, keyword) {
+				return candidate.generator
+			}
+		}
+	}
+	return "fake.Word()"
+}
+
+// fakeInt picks the fake integer range matching a lower-cased field name.
+func fakeInt(name string) string {
+	switch {
+	case strings.Contains(name, "age"):
+		return "fake.Int(18, 90)"
+	case strings.Contains(name, "year"):
+		return "fake.Int(1990, 2030)"
+	case strings.Contains(name, "quantity"), strings.Contains(name, "count"), strings.Contains(name, "stock"):
+		return "fake.Int(0, 100)"
+	}
+	return "fake.Int(1, 1000)"
+}
+
+// fakeFloat picks the fake decimal range matching a lower-cased field name.
+func fakeFloat(name string) string {
+	switch {
+	case strings.HasPrefix(name, "lat"):
+		return "fake.Float(-90, 90)"
+	case strings.HasPrefix(name, "lng"), strings.HasPrefix(name, "lon"):
+		return "fake.Float(-180, 180)"
+	case strings.Contains(name, "rating"), strings.Contains(name, "score"):
+		return "fake.Float(0, 5)"
+	}
+	return "fake.Float(1, 1000)"
+}
+
+// fakeTime picks the fake date generator matching a lower-cased field name.
+func fakeTime(name string) string {
+	switch {
+	case strings.Contains(name, "birth"):
+		return "fake.BirthDate()"
+	case strings.Contains(name, "expire"), strings.Contains(name, "due"), strings.Contains(name, "until"), strings.HasPrefix(name, "end"):
+		return "fake.FutureTime()"
+	}
+	return "fake.PastTime()"
+}
+
+// SeedDatabase inserts count fake records of a model through the generated seeder.
+// An empty model name seeds every model that has a factory.
+func SeedDatabase(modelName string, count int, dsn string) error {
+	if modelName != "" {
+		filePath := filepath.Join(factoryDir, fmt.Sprintf("%sFactory.go", utils.ToCamelCase(modelName)))
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			if err := GenerateFactory(modelName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := writeSeedRunner(); err != nil {
+		return err
+	}
+
+	args := []string{"run", "./" + filepath.ToSlash(filepath.Dir(seedRunnerPath)), "-count", fmt.Sprint(count)}
+	if modelName != "" {
+		args = append(args, "-model", utils.ToPascalCase(modelName))
+	}
+	if dsn != "" {
+		args = append(args, "-dsn", dsn)
+	}
+
+	cmd := exec.Command("go", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error seeding the database: %v", err)
+	}
+	return nil
+}
+
+// writeSeedRunner writes the seeder program listing every factory of the project.
+func writeSeedRunner() error {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	driver, ok := dialectDrivers[config.Dialect]
+	if !ok {
+		return fmt.Errorf("unsupported dialect %q, supported dialects are: postgres, mysql, sqlite", config.Dialect)
+	}
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current working directory: %v", err)
+	}
+	currentFolderName := filepath.Base(currentDir)
+
+	factoryFiles, err := filepath.Glob(filepath.Join(factoryDir, "*Factory.go"))
+	if err != nil {
+		return fmt.Errorf("error listing factories: %v", err)
+	}
+	sort.Strings(factoryFiles)
+
+	var seeders strings.Builder
+	for _, factoryFile := range factoryFiles {
+		structName := utils.ToPascalCase(strings.TrimSuffix(filepath.Base(factoryFile), "Factory.go"))
+		seeders.WriteString(fmt.Sprintf(`	"%[1]s": func(ctx context.Context, db *gorm.DB, count int) error {
+		_, err := factory.New%[1]sFactory().CreateMany(ctx, db, count)
+		return err
+	},
+`, structName))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(seedRunnerPath), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating seed directory: %v", err)
+	}
+
+	file, err := os.Create(seedRunnerPath)
+	if err != nil {
+		return fmt.Errorf("error creating seed runner: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	// Write the content
+	content := fmt.Sprintf(`// Command seed fills the database with fake records built by the model factories.
+//
+// Usage:
+//
+//	go run ./cmd/seed [-model User] [-count 10] [-dsn DSN]
+//
+// Without -model every model with a factory is seeded.
+// The connection string defaults to the DATABASE_DSN environment variable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"%[1]s/internal/app/domain/factory"
+
+	"%[2]s"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// seeders maps each model to the function inserting count fake records of it.
+var seeders = map[string]func(ctx context.Context, db *gorm.DB, count int) error{
+%[4]s}
+
+func main() {
+	modelName := flag.String("model", "", "model to seed, all models when empty")
+	count := flag.Int("count", 10, "number of records to insert")
+	dsn := flag.String("dsn", os.Getenv("DATABASE_DSN"), "database connection string")
+	flag.Parse()
+
+	if *dsn == "" {
+		log.Fatal("no connection string, set -dsn or DATABASE_DSN")
+	}
+
+	db, err := gorm.Open(%[3]s.Open(*dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		log.Fatalf("error connecting to the database: %%v", err)
+	}
+
+	names := []string{*modelName}
+	if *modelName == "" {
+		names = names[:0]
+		for name := range seeders {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	ctx := context.Background()
+	for _, name := range names {
+		seed, ok := seeders[name]
+		if !ok {
+			log.Fatalf("no factory for model %%s, run silveirinha factory %%s", name, name)
+		}
+		if err := seed(ctx, db, *count); err != nil {
+			log.Fatalf("error seeding %%s: %%v", name, err)
+		}
+		fmt.Printf("Seeded %%d %%s records\n", *count, name)
+	}
+}
+`, currentFolderName, driver, filepath.Base(driver), seeders.String())
+
+	writer.WriteString(content)
+	writer.Flush()
+
+	fmt.Printf("Seeder generated at %s, run `go get %s` if it is not yet a dependency.\n", seedRunnerPath, driver)
+	return nil
+}
+
+// writeFakeFile creates the dependency-free fake data helpers used by the factories.
+func writeFakeFile(filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	// Write the content
+	content := `// Package fake generates realistic random values for the model factories.
+package fake
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+var (
+	firstNames = []string{"Ana", "Bruno", "Carla", "Daniel", "Eduarda", "Felipe", "Gabriela", "Henrique", "Isabela", "João", "Larissa", "Lucas", "Mariana", "Pedro", "Rafaela", "Thiago"}
+	lastNames  = []string{"Almeida", "Barbosa", "Costa", "Ferreira", "Gomes", "Lima", "Martins", "Oliveira", "Pereira", "Ribeiro", "Rodrigues", "Santos", "Silva", "Silveira", "Souza"}
+	words      = []string{"alpha", "bright", "cloud", "delta", "ember", "forest", "harbor", "island", "jungle", "lunar", "meadow", "nova", "orbit", "prism", "river", "stone", "tide", "vertex"}
+	companies  = []string{"Acme", "Globex", "Initech", "Umbrella", "Stark Industries", "Wayne Enterprises", "Hooli", "Vandelay"}
+	streets    = []string{"Main Street", "Oak Avenue", "Pine Road", "Maple Lane", "Cedar Court", "Elm Street", "Lake View"}
+	cities     = []string{"São Paulo", "Porto Alegre", "Curitiba", "Lisbon", "New York", "London", "Berlin", "Tokyo"}
+	countries  = []string{"Brazil", "Portugal", "United States", "United Kingdom", "Germany", "Japan", "Canada"}
+	domains    = []string{"example.com", "example.org", "example.net"}
+)
+
+// Ptr returns a pointer to v.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// pick returns a random element of values.
+func pick(values []string) string {
+	return values[rand.Intn(len(values))]
+}
+
+// Int returns a random integer in [min, max].
+func Int(min, max int) int {
+	return min + rand.Intn(max-min+1)
+}
+
+// Float returns a random number in [min, max) rounded to two decimals.
+func Float(min, max float64) float64 {
+	value := min + rand.Float64()*(max-min)
+	return float64(int(value*100)) / 100
+}
+
+// Bool returns a random boolean.
+func Bool() bool {
+	return rand.Intn(2) == 1
+}
+
+// FirstName returns a random first name.
+func FirstName() string {
+	return pick(firstNames)
+}
+
+// LastName returns a random last name.
+func LastName() string {
+	return pick(lastNames)
+}
+
+// Name returns a random full name.
+func Name() string {
+	return FirstName() + " " + LastName()
+}
+
+// Username returns a random username.
+func Username() string {
+	return strings.ToLower(FirstName()) + fmt.Sprint(Int(1, 9999))
+}
+
+// Email returns a random email address, unique enough for seeding.
+func Email() string {
+	return fmt.Sprintf("%s.%s%d@%s", strings.ToLower(FirstName()), strings.ToLower(LastName()), Int(1, 99999), pick(domains))
+}
+
+// Password returns a random password.
+func Password() string {
+	const characters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%"
+	var password strings.Builder
+	for i := 0; i < 16; i++ {
+		password.WriteByte(characters[rand.Intn(len(characters))])
+	}
+	return password.String()
+}
+
+// Phone returns a random phone number.
+func Phone() string {
+	return fmt.Sprintf("+55 %02d 9%04d-%04d", Int(11, 99), Int(0, 9999), Int(0, 9999))
+}
+
+// URL returns a random URL.
+func URL() string {
+	return fmt.Sprintf("https://%s/%s", pick(domains), Slug())
+}
+
+// Company returns a random company name.
+func Company() string {
+	return pick(companies)
+}
+
+// Street returns a random street address.
+func Street() string {
+	return fmt.Sprintf("%d %s", Int(1, 9999), pick(streets))
+}
+
+// City returns a random city.
+func City() string {
+	return pick(cities)
+}
+
+// Country returns a random country.
+func Country() string {
+	return pick(countries)
+}
+
+// ZipCode returns a random postal code.
+func ZipCode() string {
+	return fmt.Sprintf("%05d-%03d", Int(0, 99999), Int(0, 999))
+}
+
+// Word returns a random word.
+func Word() string {
+	return pick(words)
+}
+
+// Slug returns a random url-friendly identifier.
+func Slug() string {
+	return fmt.Sprintf("%s-%s-%d", Word(), Word(), Int(1, 999))
+}
+
+// Sentence returns a random sentence.
+func Sentence() string {
+	sentence := make([]string, Int(3, 8))
+	for i := range sentence {
+		sentence[i] = Word()
+	}
+	return strings.ToUpper(sentence[0][:1]) + strings.Join(sentence, " ")[1:] + "."
+}
+
+// Paragraph returns a few random sentences.
+func Paragraph() string {
+	paragraph := make([]string, Int(2, 5))
+	for i := range paragraph {
+		paragraph[i] = Sentence()
+	}
+	return strings.Join(paragraph, " ")
+}
+
+// PastTime returns a random time within the last year.
+func PastTime() time.Time {
+	return time.Now().Add(-time.Duration(rand.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+// FutureTime returns a random time within the next year.
+func FutureTime() time.Time {
+	return time.Now().Add(time.Duration(rand.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+// BirthDate returns a random date of birth for someone between 18 and 90 years old.
+func BirthDate() time.Time {
+	return time.Now().AddDate(-Int(18, 90), -Int(0, 11), -Int(0, 27)).Truncate(24 * time.Hour)
+}
+`
+
+	writer.WriteString(content)
+	writer.Flush()
+	return nil
+}
@@ -0,0 +1,582 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// FieldAdd adds a field to a model struct from a definition like "age:int:nullable",
+// then updates the layers derived from the model. The modifiers after the type are
+// "nullable", "required", "default=<value>", "unique", "index", "index=<name>" naming a composite
+// index, "size=<n>", "precision=<n>", "scale=<n>", "json=<key>" and "column=<name>" overriding the naming strategies,
+// "description=<text>" and "example=<value>" documenting the field, and "readonly", "writeonly" or "hidden"
+// leaving it out of the requests or responses.
+func FieldAdd(modelName, definition string, migrate bool) error {
+	structName := utils.ToPascalCase(modelName)
+	field, err := parseFieldDefinition(structName, definition)
+	if err != nil {
+		return err
+	}
+
+	err = editModelStruct(structName, func(structType *ast.StructType) error {
+		if fieldIndex(structType, field.Name) >= 0 {
+			return fmt.Errorf("model %s already has a field %s", structName, field.Name)
+		}
+
+		typeExpr, err := parser.ParseExpr(field.GoType())
+		if err != nil {
+			return fmt.Errorf("error parsing type %s: %v", field.GoType(), err)
+		}
+		newField := &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(field.Name)},
+			Type:  typeExpr,
+			Tag:   &ast.BasicLit{Kind: token.STRING, Value: "`" + modelFieldTag(field) + "`"},
+		}
+
+		// New fields go before the timestamps, which close the generated structs
+		position := len(structType.Fields.List)
+		for _, timestamp := range []string{"CreatedAt", "UpdatedAt", "DeletedAt"} {
+			if index := fieldIndex(structType, timestamp); index >= 0 && index < position {
+				position = index
+			}
+		}
+		fields := append([]*ast.Field{}, structType.Fields.List[:position]...)
+		fields = append(fields, newField)
+		structType.Fields.List = append(fields, structType.Fields.List[position:]...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := documentModelField(structName, field); err != nil {
+		return err
+	}
+	if field.Enum {
+		if err := appendEnumDeclaration(structName, field); err != nil {
+			return err
+		}
+	}
+
+	printTypeDependencies([]ModelField{field})
+
+	if err := propagateModelChange(structName); err != nil {
+		return err
+	}
+	if !migrate {
+		return nil
+	}
+	return generateMigration(fmt.Sprintf("add_%s_to_%s", utils.ToSnakeCase(field.Name), utils.ToSnakeCase(structName)), schemaRenames{})
+}
+
+// FieldRemove removes a field from a model struct, with the relationship it is the foreign key of,
+// then updates the layers derived from the model.
+func FieldRemove(modelName, fieldName string, migrate bool) error {
+	structName := utils.ToPascalCase(modelName)
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+
+	var name, enumType string
+	err = editModelStruct(structName, func(structType *ast.StructType) error {
+		name = resolveFieldName(structType, fieldName)
+		if fieldIndex(structType, name) < 0 {
+			return fmt.Errorf("model %s has no field %s", structName, name)
+		}
+		if name == "ID" {
+			return fmt.Errorf("the ID field cannot be removed")
+		}
+
+		// The relationship filled through the removed foreign key goes with it
+		removed := map[string]bool{name: true}
+		model := ModelStruct{Name: structName, Fields: structType.Fields.List}
+		for _, relation := range modelRelations(model, modelStructs(models)) {
+			if relation.ForeignKey == name {
+				removed[relation.Field] = true
+			}
+		}
+
+		var fields []*ast.Field
+		for _, field := range structType.Fields.List {
+			var names []*ast.Ident
+			for _, ident := range field.Names {
+				if !removed[ident.Name] {
+					names = append(names, ident)
+				}
+			}
+			if len(field.Names) > 0 && len(names) == 0 {
+				continue
+			}
+			field.Names = names
+			fields = append(fields, field)
+		}
+
+		// The enum of the removed field goes with it, unless another field uses it
+		removedField := structType.Fields.List[fieldIndex(structType, name)]
+		if len(structEnumValues(removedField)) > 0 {
+			enumType = strings.TrimPrefix(types.ExprString(removedField.Type), "*")
+			for _, field := range fields {
+				if strings.TrimPrefix(types.ExprString(field.Type), "*") == enumType {
+					enumType = ""
+				}
+			}
+		}
+		structType.Fields.List = fields
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if enumType != "" {
+		if err := removeEnumDeclaration(structName, enumType); err != nil {
+			return err
+		}
+	}
+
+	if err := propagateModelChange(structName); err != nil {
+		return err
+	}
+	if !migrate {
+		return nil
+	}
+	return generateMigration(fmt.Sprintf("remove_%s_from_%s", utils.ToSnakeCase(name), utils.ToSnakeCase(structName)), schemaRenames{})
+}
+
+// FieldRename renames a field of a model struct, with its default JSON key and the foreign key
+// settings pointing at it, then updates the layers derived from the model. The migration renames
+// the column instead of dropping it.
+func FieldRename(modelName, oldName, newName string, migrate bool) error {
+	structName := utils.ToPascalCase(modelName)
+	to := columnFieldName(newName)
+	if !token.IsIdentifier(to) || !token.IsExported(to) {
+		return fmt.Errorf("%s is not a valid field name", newName)
+	}
+
+	var from, oldColumn, newColumn string
+	err := editModelStruct(structName, func(structType *ast.StructType) error {
+		from = resolveFieldName(structType, oldName)
+		index := fieldIndex(structType, from)
+		if index < 0 {
+			return fmt.Errorf("model %s has no field %s", structName, from)
+		}
+		if from == "ID" {
+			return fmt.Errorf("the ID field cannot be renamed")
+		}
+		if fieldIndex(structType, to) >= 0 {
+			return fmt.Errorf("model %s already has a field %s", structName, to)
+		}
+
+		field := structType.Fields.List[index]
+		for _, ident := range field.Names {
+			if ident.Name == from {
+				ident.Name = to
+			}
+		}
+
+		// An explicit column keeps the database unchanged, unless it is the one of the naming strategy
+		oldColumn, newColumn = toColumnName(from), toColumnName(to)
+		column, explicit := gormTag(field)["column"]
+		if explicit && column != "" && column != columnName(from) {
+			oldColumn, newColumn = column, column
+		} else if explicit && column != "" {
+			oldColumn, newColumn = column, columnName(to)
+		}
+
+		for _, other := range structType.Fields.List {
+			if other.Tag == nil {
+				continue
+			}
+			tag := other.Tag.Value
+			tag = strings.ReplaceAll(tag, "foreignKey:"+from+";", "foreignKey:"+to+";")
+			tag = strings.ReplaceAll(tag, "foreignKey:"+from+`"`, "foreignKey:"+to+`"`)
+			if other == field {
+				tag = strings.Replace(tag, fmt.Sprintf(`json:"%s"`, jsonKey(from)), fmt.Sprintf(`json:"%s"`, jsonKey(to)), 1)
+				tag = strings.Replace(tag, fmt.Sprintf(`json:"%s,`, jsonKey(from)), fmt.Sprintf(`json:"%s,`, jsonKey(to)), 1)
+				tag = strings.Replace(tag, "column:"+oldColumn, "column:"+newColumn, 1)
+				tag = strings.Replace(tag, "check:"+oldColumn+" IN", "check:"+newColumn+" IN", 1)
+				tag = strings.Replace(tag, `check:\"`+oldColumn+`\" IN`, `check:\"`+newColumn+`\" IN`, 1)
+			}
+			other.Tag.Value = tag
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := propagateModelChange(structName); err != nil {
+		return err
+	}
+	if !migrate {
+		return nil
+	}
+
+	schema, err := ReadModelSchema(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+	renames := map[string]map[string]string{}
+	for _, table := range schema.Tables {
+		if table.Model == structName {
+			renames[table.Name] = map[string]string{oldColumn: newColumn}
+		}
+	}
+	return generateMigration(fmt.Sprintf("rename_%s_to_%s_in_%s", utils.ToSnakeCase(from), utils.ToSnakeCase(to), utils.ToSnakeCase(structName)), schemaRenames{Columns: renames})
+}
+
+// parseFieldDefinition parses a field definition of a model like "age:int:nullable", "status:string:default=draft"
+// or "status:enum(draft,published)".
+func parseFieldDefinition(structName, definition string) (ModelField, error) {
+	parts := strings.Split(definition, ":")
+	if len(parts) < 2 {
+		return ModelField{}, fmt.Errorf("invalid field definition %q, expected name:type[:modifier...]", definition)
+	}
+
+	field := ModelField{Name: columnFieldName(parts[0]), Type: parts[1]}
+	if !token.IsIdentifier(field.Name) || !token.IsExported(field.Name) {
+		return field, fmt.Errorf("%s is not a valid field name", parts[0])
+	}
+	var values []string
+	if match := enumDefinition.FindStringSubmatch(field.Type); match != nil {
+		var err error
+		if values, err = parseEnumValues(match[1]); err != nil {
+			return field, err
+		}
+		field.Type = "string"
+	}
+	dialect := projectDialect()
+	fieldType, ok := LookupFieldType(field.Type)
+	if !ok || !fieldType.Available(dialect) {
+		var names []string
+		for _, available := range AvailableFieldTypes(dialect) {
+			names = append(names, available.GoType)
+		}
+		return field, fmt.Errorf("unsupported type %s for %s, expected one of %s", field.Type, dialect, strings.Join(names, ", "))
+	}
+	field.Rules = parseRulesTag(fieldType.Rules)
+
+	modifiers := parts[2:]
+	for i := 0; i < len(modifiers); i++ {
+		modifier := modifiers[i]
+		switch {
+		case modifier == "nullable":
+			field.Nullable = true
+		case modifier == "required":
+			field.Rules.Required = true
+		case slices.Contains(fieldAccesses, modifier):
+			if field.Access != "" {
+				return field, fmt.Errorf("%s is already %s, it cannot be %s too", field.Name, field.Access, modifier)
+			}
+			field.Access = modifier
+		case strings.HasPrefix(modifier, "default="), strings.HasPrefix(modifier, "description="), strings.HasPrefix(modifier, "example="):
+			// Values like times and sentences can have colons, so the parts up to the next modifier are kept
+			name, value, _ := strings.Cut(modifier, "=")
+			for i+1 < len(modifiers) && !isFieldModifier(modifiers[i+1]) {
+				i++
+				value += ":" + modifiers[i]
+			}
+			switch name {
+			case "default":
+				field.Default = value
+			case "description":
+				field.Description = value
+			default:
+				field.Example = value
+			}
+		case modifier == "unique":
+			field.Unique = true
+		case modifier == "index":
+			field.Indexed = true
+		case strings.HasPrefix(modifier, "index="):
+			field.Index = strings.TrimPrefix(modifier, "index=")
+		case strings.HasPrefix(modifier, "json="):
+			field.JSON = strings.TrimPrefix(modifier, "json=")
+		case strings.HasPrefix(modifier, "column="):
+			field.Column = strings.TrimPrefix(modifier, "column=")
+		case strings.HasPrefix(modifier, "size="), strings.HasPrefix(modifier, "precision="), strings.HasPrefix(modifier, "scale="):
+			name, value, _ := strings.Cut(modifier, "=")
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				return field, fmt.Errorf("invalid %s %q, expected a number", name, value)
+			}
+			switch name {
+			case "size":
+				field.Size = number
+			case "precision":
+				field.Precision = number
+			default:
+				field.Scale = number
+			}
+		default:
+			return field, fmt.Errorf("unknown modifier %q, expected nullable, required, default=<value>, unique, index, index=<name>, size=<n>, precision=<n>, scale=<n>, json=<key>, column=<name>, description=<text>, example=<value>, readonly, writeonly or hidden", modifier)
+		}
+	}
+	if err := validateDefault(&field); err != nil {
+		return field, err
+	}
+	if err := validateFieldNaming(field, nil); err != nil {
+		return field, err
+	}
+	if err := validateDescription(field); err != nil {
+		return field, err
+	}
+	if err := validateAccess(field); err != nil {
+		return field, err
+	}
+	if err := validateRequired(field); err != nil {
+		return field, err
+	}
+	if values != nil {
+		var err error
+		if field, err = enumField(structName, field, values); err != nil {
+			return field, err
+		}
+	}
+	if err := validateColumnOptions(&field); err != nil {
+		return field, err
+	}
+	return field, validateExample(&field)
+}
+
+// isFieldModifier reports whether a part of a field definition is one of the modifiers following the type.
+func isFieldModifier(part string) bool {
+	name, _, _ := strings.Cut(part, "=")
+	switch name {
+	case "nullable", "required", "default", "unique", "index", "size", "precision", "scale", "json", "column", "description", "example",
+		"readonly", "writeonly", "hidden":
+		return true
+	}
+	return false
+}
+
+// editModelStruct parses the file declaring a model struct, lets edit change the struct and writes the file back formatted.
+func editModelStruct(structName string, edit func(structType *ast.StructType) error) error {
+	files, err := filepath.Glob(filepath.Join(modelsDomainPath, "*.go"))
+	if err != nil {
+		return fmt.Errorf("error listing model files: %v", err)
+	}
+
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %v", path, err)
+		}
+
+		var structType *ast.StructType
+		ast.Inspect(file, func(node ast.Node) bool {
+			if typeSpec, ok := node.(*ast.TypeSpec); ok && typeSpec.Name.Name == structName {
+				structType, _ = typeSpec.Type.(*ast.StructType)
+			}
+			return structType == nil
+		})
+		if structType == nil {
+			continue
+		}
+
+		if err := edit(structType); err != nil {
+			return err
+		}
+		syncImports(file)
+
+		// gofmt aligns the fields again, so edited structs read like hand-written ones
+		var content bytes.Buffer
+		if err := format.Node(&content, fset, file); err != nil {
+			return fmt.Errorf("error formatting %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, groupImports(content.Bytes()), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %v", path, err)
+		}
+		fmt.Printf("Model updated at: %s\n", path)
+		return nil
+	}
+	return fmt.Errorf("model %s not found", structName)
+}
+
+// syncImports adds the imports of the standard packages, enums included, and of the registered field types
+// a model file uses, and removes them once unused.
+func syncImports(file *ast.File) {
+	packages := map[string]string{"time": "time", "json": "encoding/json", "fmt": "fmt", "slices": "slices"}
+	for _, fieldType := range fieldTypes {
+		if fieldType.Import != "" {
+			packages[fieldType.PackageName()] = fieldType.Import
+		}
+	}
+
+	used := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		syncImport(file, packages[name], used[name])
+	}
+}
+
+// syncImport adds the import of pkg to a model file when it is used, or removes it when it is not.
+func syncImport(file *ast.File, pkg string, used bool) {
+	path := strconv.Quote(pkg)
+	for i, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		for j, spec := range genDecl.Specs {
+			if importSpec := spec.(*ast.ImportSpec); importSpec.Path.Value == path {
+				if !used {
+					genDecl.Specs = append(genDecl.Specs[:j], genDecl.Specs[j+1:]...)
+					if len(genDecl.Specs) == 0 {
+						file.Decls = append(file.Decls[:i], file.Decls[i+1:]...)
+					}
+				}
+				return
+			}
+		}
+		if used {
+			// A position on the parenthesis keeps a single import valid once it has two
+			genDecl.Specs = append([]ast.Spec{&ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: path}}}, genDecl.Specs...)
+			if !genDecl.Lparen.IsValid() {
+				genDecl.Lparen = genDecl.Pos()
+			}
+			return
+		}
+	}
+
+	if used {
+		importDecl := &ast.GenDecl{Tok: token.IMPORT, Specs: []ast.Spec{&ast.ImportSpec{Path: &ast.BasicLit{Kind: token.STRING, Value: path}}}}
+		file.Decls = append([]ast.Decl{importDecl}, file.Decls...)
+	}
+}
+
+// groupImports rewrites the imports of a formatted Go file in groups, the standard library first, then
+// the packages of the project and the other ones, as the added imports are not placed in the group they belong to.
+func groupImports(content []byte) []byte {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ImportsOnly)
+	if err != nil || len(file.Imports) == 0 {
+		return content
+	}
+
+	// The module of the project is named after its folder
+	currentDir, _ := os.Getwd()
+	module := filepath.Base(currentDir) + "/"
+
+	var std, project, external []string
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		line := spec.Path.Value
+		if spec.Name != nil {
+			line = spec.Name.Name + " " + line
+		}
+		switch {
+		case strings.HasPrefix(path, module):
+			project = append(project, line)
+		case strings.Contains(strings.Split(path, "/")[0], "."):
+			external = append(external, line)
+		default:
+			std = append(std, line)
+		}
+	}
+
+	var groups []string
+	for _, group := range [][]string{std, project, external} {
+		// Sorted by path, like gofmt does
+		sort.Slice(group, func(i, j int) bool {
+			return group[i][strings.Index(group[i], `"`):] < group[j][strings.Index(group[j], `"`):]
+		})
+		if len(group) > 0 {
+			groups = append(groups, "\t"+strings.Join(group, "\n\t")+"\n")
+		}
+	}
+	block := "import (\n" + strings.Join(groups, "\n") + ")"
+	if len(file.Imports) == 1 {
+		block = "import " + strings.TrimSpace(groups[0])
+	}
+
+	start := fset.Position(file.Decls[0].Pos()).Offset
+	end := fset.Position(file.Decls[len(file.Decls)-1].End()).Offset
+	return append(append(append([]byte{}, content[:start]...), block...), content[end:]...)
+}
+
+// resolveFieldName returns the struct field a command argument names: the field spelled like
+// the argument, like "AuthorID", or else the one derived from it, like "AuthorId" for "author_id".
+func resolveFieldName(structType *ast.StructType, argument string) string {
+	if name := utils.ToPascalCase(argument); fieldIndex(structType, name) >= 0 {
+		return name
+	}
+	return columnFieldName(argument)
+}
+
+// fieldIndex returns the index of the struct field declaring name, or -1.
+func fieldIndex(structType *ast.StructType, name string) int {
+	for i, field := range structType.Fields.List {
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// propagateModelChange updates the layers derived from a model after its struct changed,
+// and its factory when it has one.
+func propagateModelChange(structName string) error {
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+	model, err := FindModel(models, structName)
+	if err != nil {
+		return err
+	}
+
+	fileName := utils.ToCamelCase(structName)
+	if _, err := os.Stat(filepath.Join("internal/app/transport/inbound", fileName+".go")); err != nil {
+		fmt.Printf("Model %s has no generated layers, run `silveirinha scaffold %s` to generate them\n", structName, structName)
+	} else {
+		spec, options, err := structModelSpec(*model, modelStructs(models))
+		if err != nil {
+			return err
+		}
+		changes, err := syncModel(fileName, spec, options)
+		if err != nil {
+			return fmt.Errorf("error syncing model %s: %v", structName, err)
+		}
+		for _, change := range changes {
+			fmt.Printf("- %s\n", change)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(factoryDir, fileName+"Factory.go")); err == nil {
+		return generateFactoryFile(models, structName, map[string]bool{}, true)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldType describes a Go type model fields can be declared with, and how the generated layers handle it.
+// The basic Go types only need a Go type: their SQL types and fake data are derived from it.
+type FieldType struct {
+	// GoType is the type as written in the models, like "decimal.Decimal".
+	GoType string `json:"goType"`
+
+	// Import is the path of the package declaring the type, imported by the files using it.
+	Import string `json:"import,omitempty"`
+
+	// GormTag holds GORM settings added to the tag of the fields, besides their type, like "serializer:json".
+	GormTag string `json:"gormTag,omitempty"`
+
+	// SQLTypes maps each dialect that can store the type to its SQL type. Types without SQL types
+	// are mapped by the dialects themselves.
+	SQLTypes map[string]string `json:"sqlTypes,omitempty"`
+
+	// Default is the GORM default of non-nullable fields, for types whose zero value is stored as NULL.
+	Default string `json:"default,omitempty"`
+
+	// SwaggerType and SwaggerFormat document the JSON value of the type, like "string" and "uuid".
+	SwaggerType   string `json:"swaggerType,omitempty"`
+	SwaggerFormat string `json:"swaggerFormat,omitempty"`
+
+	// Rules are the input rules new fields of the type get, written like the rules tag, like "format=uuid".
+	Rules string `json:"rules,omitempty"`
+
+	// Fake is the Go expression filling the type in factories. It can call the generated fake package.
+	Fake string `json:"fake,omitempty"`
+
+	// Sample is a JSON value of the type, sent in the request bodies of the generated handler tests.
+	Sample json.RawMessage `json:"sample,omitempty"`
+}
+
+// fieldTypes lists the registered types in the order of the selection menu.
+var fieldTypes []FieldType
+
+func init() {
+	for _, goType := range []string{
+		"int", "uint", "int8", "uint8", "int16", "uint16", "int32", "uint32", "int64", "uint64",
+		"string", "float32", "float64", "bool", "byte", "rune", "time.Time", "[]byte",
+	} {
+		RegisterFieldType(FieldType{GoType: goType})
+	}
+
+	RegisterFieldType(FieldType{
+		GoType:        "decimal.Decimal",
+		Import:        "github.com/shopspring/decimal",
+		SQLTypes:      map[string]string{"postgres": "numeric(20,8)", "mysql": "decimal(20,8)", "sqlite": "numeric(20,8)"},
+		SwaggerType:   "string",
+		SwaggerFormat: "decimal",
+		Fake:          "decimal.NewFromFloat(fake.Float(1, 1000))",
+		Sample:        json.RawMessage(`"9.99"`),
+	})
+	RegisterFieldType(FieldType{
+		GoType:        "uuid.UUID",
+		Import:        "github.com/google/uuid",
+		SQLTypes:      map[string]string{"postgres": "uuid", "mysql": "char(36)", "sqlite": "text"},
+		SwaggerType:   "string",
+		SwaggerFormat: "uuid",
+		Fake:          "uuid.New()",
+		Sample:        json.RawMessage(`"123e4567-e89b-12d3-a456-426614174000"`),
+	})
+	RegisterFieldType(FieldType{
+		GoType:      "datatypes.JSON",
+		Import:      "gorm.io/datatypes",
+		SQLTypes:    map[string]string{"postgres": "jsonb", "mysql": "json", "sqlite": "json"},
+		Default:     "('{}')",
+		SwaggerType: "object",
+		Fake:        `datatypes.JSON("{}")`,
+		Sample:      json.RawMessage(`{"key":"value"}`),
+	})
+
+	// Arrays are only stored by PostgreSQL
+	for _, array := range []struct{ goType, sqlType, itemType, fake, sample string }{
+		{"pq.StringArray", "text[]", "string", "pq.StringArray{fake.Word(), fake.Word()}", `["a"]`},
+		{"pq.Int64Array", "bigint[]", "integer", "pq.Int64Array{int64(fake.Int(1, 100))}", `[1]`},
+		{"pq.Float64Array", "double precision[]", "number", "pq.Float64Array{fake.Float(1, 100)}", `[1.5]`},
+		{"pq.BoolArray", "boolean[]", "boolean", "pq.BoolArray{fake.Bool()}", `[true]`},
+	} {
+		RegisterFieldType(FieldType{
+			GoType:      array.goType,
+			Import:      "github.com/lib/pq",
+			SQLTypes:    map[string]string{"postgres": array.sqlType},
+			Default:     "'{}'",
+			SwaggerType: "array," + array.itemType,
+			Fake:        array.fake,
+			Sample:      json.RawMessage(array.sample),
+		})
+	}
+}
+
+// RegisterFieldType adds a type to the registry, or replaces the one registered with the same Go type.
+func RegisterFieldType(fieldType FieldType) {
+	for i, registered := range fieldTypes {
+		if registered.GoType == fieldType.GoType {
+			fieldTypes[i] = fieldType
+			return
+		}
+	}
+	fieldTypes = append(fieldTypes, fieldType)
+}
+
+// LookupFieldType returns the registered type of a Go type, without the pointer of nullable fields.
+func LookupFieldType(goType string) (FieldType, bool) {
+	for _, fieldType := range fieldTypes {
+		if fieldType.GoType == goType {
+			return fieldType, true
+		}
+	}
+	return FieldType{}, false
+}
+
+// AvailableFieldTypes returns the registered types a dialect can store, in the order of the selection menu.
+func AvailableFieldTypes(dialect string) []FieldType {
+	var available []FieldType
+	for _, fieldType := range fieldTypes {
+		if fieldType.Available(dialect) {
+			available = append(available, fieldType)
+		}
+	}
+	return available
+}
+
+// Available reports whether a dialect can store the type.
+func (t FieldType) Available(dialect string) bool {
+	return len(t.SQLTypes) == 0 || t.SQLTypes[dialect] != ""
+}
+
+// PackageName returns the name the type is qualified with, like "decimal" for "decimal.Decimal".
+func (t FieldType) PackageName() string {
+	if t.Import == "" {
+		return ""
+	}
+	// Major versions are not part of the name, like in "github.com/jackc/pgx/v5" and "gopkg.in/guregu/null.v4"
+	name := path.Base(t.Import)
+	if regexp.MustCompile(`^v[0-9]+$`).MatchString(name) {
+		name = path.Base(path.Dir(t.Import))
+	}
+	return regexp.MustCompile(`\.v[0-9]+$`).ReplaceAllString(name, "")
+}
+
+// validate checks a type registered from the project configuration.
+func (t FieldType) validate() error {
+	if t.GoType == "" {
+		return fmt.Errorf("a type has no goType")
+	}
+	if t.Import != "" && !strings.HasPrefix(t.GoType, t.PackageName()+".") {
+		return fmt.Errorf("type %s is not declared by its import %s", t.GoType, t.Import)
+	}
+	if len(t.Sample) > 0 && !json.Valid(t.Sample) {
+		return fmt.Errorf("the sample of type %s is not valid JSON", t.GoType)
+	}
+	return nil
+}
+
+// projectDialect returns the dialect configured for the project, postgres when it cannot be read.
+func projectDialect() string {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return DefaultProjectConfig().Dialect
+	}
+	return config.Dialect
+}
+
+// fieldImports returns the standard library and the other packages the types of fields are declared in, sorted.
+func fieldImports(fields []ModelField) (std, external []string) {
+	seen := map[string]bool{}
+	for _, field := range fields {
+		pkg := ""
+		if field.Type == "time.Time" {
+			pkg = "time"
+		} else if fieldType, ok := LookupFieldType(field.Type); ok {
+			pkg = fieldType.Import
+		}
+		if pkg == "" || seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		if strings.Contains(strings.Split(pkg, "/")[0], ".") {
+			external = append(external, pkg)
+		} else {
+			std = append(std, pkg)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(external)
+	return std, external
+}
+
+// importBlock renders the import declaration of a generated file, the standard library first.
+func importBlock(std, external []string) string {
+	var groups []string
+	for _, group := range [][]string{std, external} {
+		if len(group) == 0 {
+			continue
+		}
+		lines := make([]string, len(group))
+		for i, pkg := range group {
+			lines[i] = fmt.Sprintf("\t%q\n", pkg)
+		}
+		groups = append(groups, strings.Join(lines, ""))
+	}
+
+	switch {
+	case len(groups) == 0:
+		return ""
+	case len(groups) == 1 && len(std)+len(external) == 1:
+		return "import " + strings.TrimSpace(groups[0]) + "\n\n"
+	}
+	return "import (\n" + strings.Join(groups, "\n") + ")\n\n"
+}
+
+// swaggerTag returns the swaggertype, format, enums and example tags documenting a field, with a leading space,
+// or an empty string. Defaults are the examples of the fields without one.
+func swaggerTag(field ModelField) string {
+	tag := ""
+	if field.Enum {
+		tag = fmt.Sprintf(` enums:"%s"`, strings.Join(field.Rules.Enum, ","))
+	} else if fieldType, ok := LookupFieldType(field.Type); ok && fieldType.SwaggerType != "" {
+		tag = fmt.Sprintf(` swaggertype:"%s"`, fieldType.SwaggerType)
+		if fieldType.SwaggerFormat != "" {
+			tag += fmt.Sprintf(` format:"%s"`, fieldType.SwaggerFormat)
+		}
+	}
+	if example := fieldExample(field); example != "" {
+		tag += " example:" + strconv.Quote(example)
+	}
+	return tag
+}
+
+// printTypeDependencies lists the modules the types of fields come from, which the project has to require.
+func printTypeDependencies(fields []ModelField) {
+	_, external := fieldImports(fields)
+	for _, pkg := range external {
+		fmt.Printf("Fields use types of %s, run `go get %s` if it is not yet a dependency.\n", pkg, pkg)
+	}
+}
@@ -2,14 +2,19 @@ package commands
 
 import (
 	"fmt"
-	"github.com/lucassilveira96/silveirinha/utils"
+	"github.com/lucassilveira96/silveirinha/inflection"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
 // GenerateHandler generates a handler file for a given model in Go.
-func GenerateHandler(modelName, structName string) error {
+// The spec fields give the request bodies of the handler tests.
+func GenerateHandler(modelName string, spec ModelSpec, options ModelOptions) error {
+	structName := spec.Name
+
 	// Get the current working directory
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -29,10 +34,16 @@ func GenerateHandler(modelName, structName string) error {
 	}
 
 	// Create and write to the handler file
-	if err := writeHandlerFile(handlerFilePath, currentFolderName, modelName, structName); err != nil {
+	if err := writeHandlerFile(handlerFilePath, currentFolderName, modelName, structName, options); err != nil {
 		return fmt.Errorf("error writing handler file: %v", err)
 	}
 
+	// Create and write the handler test file next to the handler
+	handlerTestFilePath := filepath.Join(handlerDir, fmt.Sprintf("%sHandler_test.go", modelName))
+	if err := writeHandlerTestFile(handlerTestFilePath, currentFolderName, modelName, spec, options); err != nil {
+		return fmt.Errorf("error writing handler test file: %v", err)
+	}
+
 	// Update the `handlers.go` file
 	handlersFilePath := filepath.Join("internal", "app", "adapter", "handlers.go")
 	if err := updateHandlersFile(handlersFilePath, modelName, structName, currentFolderName); err != nil {
@@ -44,237 +55,844 @@ func GenerateHandler(modelName, structName string) error {
 }
 
 // writeHandlerFile generates the content of the handler file, including Swagger documentation.
-func writeHandlerFile(filePath, currentFolderName, modelName, structName string) error {
+// The request context is forwarded to the service layer through c.UserContext().
+// Request bodies are parsed into the inbound model, validated and mapped to the domain model,
+// and the records sent back are mapped to the outbound model.
+// Soft-deleted models also get admin routes to list, restore and purge trashed records.
+func writeHandlerFile(filePath, currentFolderName, modelName, structName string, options ModelOptions) error {
 	file, err := os.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("error creating handler file: %v", err)
 	}
 	defer file.Close()
 
-	content := fmt.Sprintf(`package handler
+	routes := options.handlerRoutes(modelName)
+	key := options.key()
+	softDeleteRoutes := ""
+	softDeleteHandlers := ""
+	if options.SoftDelete {
+		softDeleteRoutes = fmt.Sprintf(`
+	// %[1]s Admin Routes
+	adminRoute := route + "/admin/%[3]s"
+	server.Get(adminRoute+"/trashed", h.getTrashed%[4]s)
+	server.Patch(adminRoute+"/:id/restore", h.restore%[2]s)
+	server.Delete(adminRoute+"/:id/force", h.forceDelete%[2]s)
+`, modelName, structName, resourcePath(structName), inflection.Pluralize(structName))
+		softDeleteHandlers = fmt.Sprintf(`
+// @Summary Get trashed %[4]s
+// @Description Get all soft-deleted %[4]s from the system
+// @Tags %[5]s
+// @Accept json
+// @Produce json
+// @Success 200 {array} outbound.%[2]s "Success"
+// @Router /api/v1/admin/%[3]s/trashed [get]
+func (h *%[2]sHandler) getTrashed%[4]s(c *fiber.Ctx) error {
+	%[6]s, err := h.services.%[2]sService.FindTrashed(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(presenter.Success("Data retrieved successfully", mapper.%[2]sListMapToOutbound(%[6]s)))
+}
 
-import (
-	"%s/internal/app/domain"
-	"%s/internal/app/domain/model"
-	"%s/internal/app/transport/presenter"
-	"%s/internal/infra/variables"
-	"strconv"
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/swagger"
-)
+// @Summary Restore a %[2]s
+// @Description Restore a soft-deleted %[2]s by ID
+// @Tags %[5]s
+// @Accept json
+// @Produce json
+// @Param id path %[7]s true "%[2]s ID"
+// @Success 200 "Restored successfully"
+// @Router /api/v1/admin/%[3]s/{id}/restore [patch]
+func (h *%[2]sHandler) restore%[2]s(c *fiber.Ctx) error {
+	%[8]s
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	err = h.services.%[2]sService.Restore(c.UserContext(), %[9]s)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "%[2]s not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(presenter.Success("Restored successfully", nil))
+}
+
+// @Summary Permanently delete a %[2]s
+// @Description Remove a %[2]s by ID from the database, including soft-deleted ones
+// @Tags %[5]s
+// @Accept json
+// @Produce json
+// @Param id path %[7]s true "%[2]s ID"
+// @Success 200 "Deleted permanently"
+// @Router /api/v1/admin/%[3]s/{id}/force [delete]
+func (h *%[2]sHandler) forceDelete%[2]s(c *fiber.Ctx) error {
+	%[8]s
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
 
-type %sHandler struct {
+	err = h.services.%[2]sService.ForceDelete(c.UserContext(), %[9]s)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "%[2]s not found"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(presenter.Success("Deleted permanently", nil))
+}
+`, modelName, structName, resourcePath(structName), inflection.Pluralize(structName), routes.List.tag(structName),
+			modelVariable(inflection.Pluralize(structName)), key.Swagger, key.ParseID("id"), key.Arg)
+	}
+
+	routeLines, handlers := handlerOperations(modelName, structName, routes, key)
+
+	body := fmt.Sprintf(`type %[2]sHandler struct {
 	services *domain.Services
 }
 
-func New%sHandler(services *domain.Services) *%sHandler {
-	return &%sHandler{
+func New%[2]sHandler(services *domain.Services) *%[2]sHandler {
+	return &%[2]sHandler{
 		services: services,
 	}
 }
 
-func (h *%sHandler) Configure(server *fiber.App) {
+func (h *%[2]sHandler) Configure(server *fiber.App) {
 	route := variables.PrefixRoute()
 	server.Get(route+"/swagger/*", swagger.HandlerDefault)
 
-	// %s Routes
-	serviceRoute := route + "/%s"
-	server.Get(serviceRoute, h.getAll%ss)
-	server.Get(serviceRoute+"/:id", h.get%sById)
-	server.Post(serviceRoute, h.create%s)
-	server.Put(serviceRoute+"/:id", h.update%s)
-	server.Delete(serviceRoute+"/:id", h.delete%s)
+	// %[1]s Routes
+%[3]s%[4]s}
+%[5]s%[6]s`, modelName, structName, routeLines, softDeleteRoutes, handlers, softDeleteHandlers)
+
+	// Only the packages the generated routes use are imported, Swagger comments aside
+	var code strings.Builder
+	for _, line := range strings.Split(body, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			code.WriteString(line + "\n")
+		}
+	}
+	var imports []string
+	for _, pkg := range []string{"domain", "domain/model", "transport/inbound", "transport/mapper", "transport/presenter"} {
+		if pkg == "domain" || strings.Contains(code.String(), filepath.Base(pkg)+".") {
+			imports = append(imports, fmt.Sprintf("\t\"%s/internal/app/%s\"\n", currentFolderName, pkg))
+		}
+	}
+	imports = append(imports, fmt.Sprintf("\t\"%s/internal/infra/variables\"\n", currentFolderName))
+	for _, pkg := range []string{"errors", "strconv"} {
+		if strings.Contains(code.String(), pkg+".") {
+			imports = append(imports, fmt.Sprintf("\t\"%s\"\n", pkg))
+		}
+	}
+	imports = append(imports, "\t\"github.com/gofiber/fiber/v2\"\n", "\t\"github.com/gofiber/swagger\"\n")
+	if line := key.ImportLine(code.String()); line != "" {
+		imports = append(imports, line)
+	}
+	if strings.Contains(code.String(), "gorm.") {
+		imports = append(imports, "\t\"gorm.io/gorm\"\n")
+	}
+
+	content := "package handler\n\nimport (\n" + strings.Join(imports, "") + ")\n\n" + body
+
+	_, err = file.WriteString(content)
+	return err
 }
 
-// @Summary Get all %ss
-// @Description Get all %ss from the system
-// @Tags %ss
+// handlerOperations returns the route registrations and the handler functions of the CRUD routes,
+// parsing the ID path parameters as keys of the key type.
+func handlerOperations(modelName, structName string, routes *HandlerRoutes, key KeyType) (string, string) {
+	variable, listVariable := modelVariable(modelName), modelVariable(inflection.Pluralize(structName))
+
+	// Routes under the collection path of the list or create route are registered relative to serviceRoute
+	collection := ""
+	switch {
+	case routes.List != nil:
+		collection = routes.List.Path
+	case routes.Create != nil:
+		collection = routes.Create.Path
+	}
+
+	operations := []struct {
+		op      *HandlerOperation
+		handler string
+	}{
+		{routes.List, fmt.Sprintf("getAll%s", inflection.Pluralize(structName))},
+		{routes.Get, fmt.Sprintf("get%sById", structName)},
+		{routes.Create, fmt.Sprintf("create%s", structName)},
+		{routes.Update, fmt.Sprintf("update%s", structName)},
+		{routes.Delete, fmt.Sprintf("delete%s", structName)},
+	}
+
+	var routeLines strings.Builder
+	if collection != "" {
+		routeLines.WriteString(fmt.Sprintf("\tserviceRoute := route + %q\n", collection))
+	}
+	for _, operation := range operations {
+		if operation.op == nil {
+			continue
+		}
+		path := fmt.Sprintf("route+%q", operation.op.Path)
+		if collection != "" && operation.op.Path == collection {
+			path = "serviceRoute"
+		} else if collection != "" && strings.HasPrefix(operation.op.Path, collection+"/") {
+			path = fmt.Sprintf("serviceRoute+%q", strings.TrimPrefix(operation.op.Path, collection))
+		}
+		routeLines.WriteString(fmt.Sprintf("\tserver.%s(%s, h.%s)\n", fiberMethod(operation.op.Method), path, operation.handler))
+	}
+
+	var handlers strings.Builder
+	if op := routes.List; op != nil {
+		handlers.WriteString(fmt.Sprintf(`
+// @Summary %[3]s
+// @Description Get all %[9]s from the system
+// @Tags %[4]s
 // @Accept json
 // @Produce json
-// @Success 200 {array} model.%s "Success"
-// @Router /api/v1/%s [get]
-func (h *%sHandler) getAll%ss(c *fiber.Ctx) error { 
-	%ss, err := h.services.%sService.FindAll()
+// @Success %[5]d {array} outbound.%[2]s "Success"
+// @Router /api/v1%[6]s [%[7]s]
+func (h *%[2]sHandler) getAll%[9]s(c *fiber.Ctx) error {
+	%[1]s, err := h.services.%[2]sService.FindAll(c.UserContext())
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(presenter.Success("Data retrieved successfully", %ss))
+	return %[8]s
 }
-
-// @Summary Get %s by ID
-// @Description Get a %s by ID from the system
-// @Tags %ss
+`, listVariable, structName, op.summary("Get all "+inflection.Pluralize(structName)), op.tag(structName), op.Status, op.SwaggerPath(), strings.ToLower(op.Method),
+			op.response(fmt.Sprintf(`presenter.Success("Data retrieved successfully", mapper.%sListMapToOutbound(%s))`, structName, listVariable)), inflection.Pluralize(structName)))
+	}
+	if op := routes.Get; op != nil {
+		handlers.WriteString(fmt.Sprintf(`
+// @Summary %[3]s
+// @Description Get a %[2]s by ID from the system
+// @Tags %[4]s
 // @Accept json
 // @Produce json
-// @Param id path int true "%s ID"
-// @Success 200 {object} model.%s "Success"
-// @Router /api/v1/%s/{id} [get]
-func (h *%sHandler) get%sById(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
+// @Param %[9]s path %[10]s true "%[2]s ID"
+// @Success %[5]d {object} outbound.%[2]s "Success"
+// @Router /api/v1%[6]s [%[7]s]
+func (h *%[2]sHandler) get%[2]sById(c *fiber.Ctx) error {
+	%[11]s
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
 	}
 
-	%s, err := h.services.%sService.FindById(uint(id))
+	%[1]s, err := h.services.%[2]sService.FindById(c.UserContext(), %[12]s)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "%[2]s not found"})
+	}
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "%s not found"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(%s)
+	return %[8]s
 }
-
-// @Summary Create a new %s
-// @Description Create a new %s in the system
-// @Tags %ss
+`, variable, structName, op.summary("Get "+structName+" by ID"), op.tag(structName), op.Status, op.SwaggerPath(), strings.ToLower(op.Method),
+			op.response(fmt.Sprintf("mapper.%sMapToOutbound(*%s)", structName, variable)), op.IDParam(), key.Swagger, key.ParseID(op.IDParam()), key.Arg))
+	}
+	if op := routes.Create; op != nil {
+		handlers.WriteString(fmt.Sprintf(`
+// @Summary %[3]s
+// @Description Create a new %[2]s in the system
+// @Tags %[4]s
 // @Accept json
 // @Produce json
-// @Param %s body model.%s true "%s Data"
-// @Success 201 {object} model.%s "Created"
-// @Router /api/v1/%s [post]
-func (h *%sHandler) create%s(c *fiber.Ctx) error {
-	%s := new(model.%s) 
-	if err := c.BodyParser(%s); err != nil {
+// @Param %[2]s body inbound.%[2]s true "%[2]s Data"
+// @Success %[5]d {object} outbound.%[2]s "Created"
+// @Router /api/v1%[6]s [%[7]s]
+func (h *%[2]sHandler) create%[2]s(c *fiber.Ctx) error {
+	input := new(inbound.%[2]s)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := input.Validate(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if err := h.services.%sService.Create(%s); err != nil {
+	%[1]s := mapper.%[2]sMapToModel(*input)
+	err := h.services.%[2]sService.Create(c.UserContext(), &%[1]s)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "%[2]s conflicts with an existing record"})
+	}
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.Status(fiber.StatusCreated).JSON(presenter.Success("Success", %s))
+	return %[8]s
 }
-
-// @Summary Update an existing %s
-// @Description Update a %s by ID in the system
-// @Tags %ss
+`, variable, structName, op.summary("Create a new "+structName), op.tag(structName), op.Status, op.SwaggerPath(), strings.ToLower(op.Method),
+			op.response(fmt.Sprintf(`presenter.Success("Success", mapper.%sMapToOutbound(%s))`, structName, variable))))
+	}
+	if op := routes.Update; op != nil {
+		handlers.WriteString(fmt.Sprintf(`
+// @Summary %[3]s
+// @Description Update a %[2]s by ID in the system
+// @Tags %[4]s
 // @Accept json
 // @Produce json
-// @Param id path int true "%s ID"
-// @Param %s body model.%s true "%s Data"
-// @Success 200 {object} model.%s "Updated"
-// @Router /api/v1/%s/{id} [put]
-func (h *%sHandler) update%s(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
+// @Param %[9]s path %[10]s true "%[2]s ID"
+// @Param %[2]s body inbound.%[2]s true "%[2]s Data"
+// @Success %[5]d {object} outbound.%[2]s "Updated"
+// @Router /api/v1%[6]s [%[7]s]
+func (h *%[2]sHandler) update%[2]s(c *fiber.Ctx) error {
+	%[11]s
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
 	}
 
-	%s := new(model.%s)
-	if err := c.BodyParser(%s); err != nil {
+	input := new(inbound.%[2]s)
+	if err := c.BodyParser(input); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := input.Validate(); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	%s.ID = uint(id)
-	if err := h.services.%sService.Update(%s.ID, %s); err != nil {
+	%[1]s := mapper.%[2]sMapToModel(*input)
+	%[1]s.ID = %[12]s
+	err = h.services.%[2]sService.Update(c.UserContext(), %[1]s.ID, &%[1]s)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "%[2]s not found"})
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "%[2]s conflicts with an existing record"})
+	}
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(presenter.Success("Updated successfully", %s))
+	return %[8]s
 }
-
-// @Summary Delete a %s
-// @Description Delete a %s by ID in the system
-// @Tags %ss
+`, variable, structName, op.summary("Update an existing "+structName), op.tag(structName), op.Status, op.SwaggerPath(), strings.ToLower(op.Method),
+			op.response(fmt.Sprintf(`presenter.Success("Updated successfully", mapper.%sMapToOutbound(%s))`, structName, variable)), op.IDParam(), key.Swagger, key.ParseID(op.IDParam()), key.Arg))
+	}
+	if op := routes.Delete; op != nil {
+		handlers.WriteString(fmt.Sprintf(`
+// @Summary %[3]s
+// @Description Delete a %[2]s by ID in the system
+// @Tags %[4]s
 // @Accept json
 // @Produce json
-// @Param id path int true "%s ID"
-// @Success 204 "Deleted successfully"
-// @Router /api/v1/%s/{id} [delete]
-func (h *%sHandler) delete%s(c *fiber.Ctx) error {
-	id, err := strconv.Atoi(c.Params("id"))
+// @Param %[9]s path %[10]s true "%[2]s ID"
+// @Success %[5]d "Deleted successfully"
+// @Router /api/v1%[6]s [%[7]s]
+func (h *%[2]sHandler) delete%[2]s(c *fiber.Ctx) error {
+	%[11]s
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
 	}
 
-	if err := h.services.%sService.Delete(uint(id)); err != nil {
+	err = h.services.%[2]sService.Delete(c.UserContext(), %[12]s)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "%[2]s not found"})
+	}
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(presenter.Success("Deleted successfully", nil))
+	return %[8]s
+}
+`, variable, structName, op.summary("Delete a "+structName), op.tag(structName), op.Status, op.SwaggerPath(), strings.ToLower(op.Method),
+			op.response(`presenter.Success("Deleted successfully", nil)`), op.IDParam(), key.Swagger, key.ParseID(op.IDParam()), key.Arg))
+	}
+
+	return routeLines.String(), handlers.String()
+}
+
+// summary returns the Swagger summary of the operation, or fallback when the operation has none.
+func (op *HandlerOperation) summary(fallback string) string {
+	if op.Summary != "" {
+		return op.Summary
+	}
+	return fallback
+}
+
+// tag returns the Swagger tag of the operation, the plural struct name by default.
+func (op *HandlerOperation) tag(structName string) string {
+	if op != nil && op.Tag != "" {
+		return op.Tag
+	}
+	return inflection.Pluralize(structName)
+}
+
+// response returns the statement sending value with the success status of the operation.
+func (op *HandlerOperation) response(value string) string {
+	switch op.Status {
+	case http.StatusOK:
+		return fmt.Sprintf("c.JSON(%s)", value)
+	case http.StatusNoContent:
+		return "c.SendStatus(fiber.StatusNoContent)"
+	}
+	return fmt.Sprintf("c.Status(%s).JSON(%s)", fiberStatus(op.Status), value)
+}
+
+// writeHandlerTestFile generates a table-driven test for every route of the handler.
+// The handler is registered on a fiber.App backed by the generated service mock, so the
+// test runs without a database. Create and update requests send a body passing validation.
+func writeHandlerTestFile(filePath, currentFolderName, modelName string, spec ModelSpec, options ModelOptions) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating handler test file: %v", err)
+	}
+	defer file.Close()
+
+	structName := spec.Name
+	key := options.key()
+	variable := modelVariable(modelName)
+
+	softDeleteCases := ""
+	softDeleteRoute := ""
+	if options.SoftDelete {
+		softDeleteRoute = fmt.Sprintf("\tadminRoute := route + \"/admin/%s\"\n", resourcePath(structName))
+		softDeleteCases = fmt.Sprintf(`		{
+			name:   "get trashed",
+			method: http.MethodGet,
+			path:   adminRoute + "/trashed",
+			service: &mocks.%[1]sServiceMock{
+				FindTrashedFunc: func(ctx context.Context) ([]*model.%[1]s, error) {
+					return []*model.%[1]s{{}}, nil
+				},
+			},
+			wantCall: "FindTrashed",
+			status:   fiber.StatusOK,
+		},
+		{
+			name:   "get trashed service error",
+			method: http.MethodGet,
+			path:   adminRoute + "/trashed",
+			service: &mocks.%[1]sServiceMock{
+				FindTrashedFunc: func(ctx context.Context) ([]*model.%[1]s, error) {
+					return nil, errService
+				},
+			},
+			wantCall: "FindTrashed",
+			status:   fiber.StatusInternalServerError,
+		},
+		{
+			name:     "restore",
+			method:   http.MethodPatch,
+			path:     adminRoute + "/%[3]s/restore",
+			service:  &mocks.%[1]sServiceMock{},
+			wantCall: "Restore",
+			status:   fiber.StatusOK,
+		},
+		{
+			name:    "restore invalid id",
+			method:  http.MethodPatch,
+			path:    adminRoute + "/abc/restore",
+			service: &mocks.%[1]sServiceMock{},
+			status:  fiber.StatusBadRequest,
+		},
+		{
+			name:   "restore not found",
+			method: http.MethodPatch,
+			path:   adminRoute + "/%[3]s/restore",
+			service: &mocks.%[1]sServiceMock{
+				RestoreFunc: func(ctx context.Context, id %[2]s) error {
+					return gorm.ErrRecordNotFound
+				},
+			},
+			wantCall: "Restore",
+			status:   fiber.StatusNotFound,
+		},
+		{
+			name:   "restore service error",
+			method: http.MethodPatch,
+			path:   adminRoute + "/%[3]s/restore",
+			service: &mocks.%[1]sServiceMock{
+				RestoreFunc: func(ctx context.Context, id %[2]s) error {
+					return errService
+				},
+			},
+			wantCall: "Restore",
+			status:   fiber.StatusInternalServerError,
+		},
+		{
+			name:     "force delete",
+			method:   http.MethodDelete,
+			path:     adminRoute + "/%[3]s/force",
+			service:  &mocks.%[1]sServiceMock{},
+			wantCall: "ForceDelete",
+			status:   fiber.StatusOK,
+		},
+		{
+			name:    "force delete invalid id",
+			method:  http.MethodDelete,
+			path:    adminRoute + "/abc/force",
+			service: &mocks.%[1]sServiceMock{},
+			status:  fiber.StatusBadRequest,
+		},
+		{
+			name:   "force delete not found",
+			method: http.MethodDelete,
+			path:   adminRoute + "/%[3]s/force",
+			service: &mocks.%[1]sServiceMock{
+				ForceDeleteFunc: func(ctx context.Context, id %[2]s) error {
+					return gorm.ErrRecordNotFound
+				},
+			},
+			wantCall: "ForceDelete",
+			status:   fiber.StatusNotFound,
+		},
+		{
+			name:   "force delete service error",
+			method: http.MethodDelete,
+			path:   adminRoute + "/%[3]s/force",
+			service: &mocks.%[1]sServiceMock{
+				ForceDeleteFunc: func(ctx context.Context, id %[2]s) error {
+					return errService
+				},
+			},
+			wantCall: "ForceDelete",
+			status:   fiber.StatusInternalServerError,
+		},
+`, structName, key.GoType, key.TestID)
+	}
+
+	routes := options.handlerRoutes(modelName)
+	var cases strings.Builder
+	if op := routes.List; op != nil {
+		cases.WriteString(fmt.Sprintf(`		{
+			name:   "get all",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			service: &mocks.%[2]sServiceMock{
+				FindAllFunc: func(ctx context.Context) ([]*model.%[2]s, error) {
+					return []*model.%[2]s{{}}, nil
+				},
+			},
+			wantCall: "FindAll",
+			status:   %[5]s,
+		},
+		{
+			name:   "get all service error",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			service: &mocks.%[2]sServiceMock{
+				FindAllFunc: func(ctx context.Context) ([]*model.%[2]s, error) {
+					return nil, errService
+				},
+			},
+			wantCall: "FindAll",
+			status:   fiber.StatusInternalServerError,
+		},
+`, variable, structName, fiberMethod(op.Method), op.Path, fiberStatus(op.Status)))
+	}
+	if op := routes.Get; op != nil {
+		cases.WriteString(fmt.Sprintf(`		{
+			name:   "get by id",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			service: &mocks.%[2]sServiceMock{
+				FindByIdFunc: func(ctx context.Context, id %[7]s) (*model.%[2]s, error) {
+					found := &model.%[2]s{}
+					found.ID = id
+					return found, nil
+				},
+			},
+			wantCall: "FindById",
+			status:   %[6]s,
+		},
+		{
+			name:    "get by id invalid id",
+			method:  http.Method%[3]s,
+			path:    route + %[5]q,
+			service: &mocks.%[2]sServiceMock{},
+			status:  fiber.StatusBadRequest,
+		},
+		{
+			name:   "get by id not found",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			service: &mocks.%[2]sServiceMock{
+				FindByIdFunc: func(ctx context.Context, id %[7]s) (*model.%[2]s, error) {
+					return nil, gorm.ErrRecordNotFound
+				},
+			},
+			wantCall: "FindById",
+			status:   fiber.StatusNotFound,
+		},
+		{
+			name:   "get by id service error",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			service: &mocks.%[2]sServiceMock{
+				FindByIdFunc: func(ctx context.Context, id %[7]s) (*model.%[2]s, error) {
+					return nil, errService
+				},
+			},
+			wantCall: "FindById",
+			status:   fiber.StatusInternalServerError,
+		},
+`, variable, structName, fiberMethod(op.Method), op.TestPath(key.TestID), op.TestPath("abc"), fiberStatus(op.Status), key.GoType))
+	}
+	if op := routes.Create; op != nil {
+		cases.WriteString(fmt.Sprintf(`		{
+			name:     "create",
+			method:   http.Method%[3]s,
+			path:     route + %[4]q,
+			body:     validBody,
+			service:  &mocks.%[2]sServiceMock{},
+			wantCall: "Create",
+			status:   %[5]s,
+		},
+		{
+			name:    "create bad body",
+			method:  http.Method%[3]s,
+			path:    route + %[4]q,
+			body:    "{",
+			service: &mocks.%[2]sServiceMock{},
+			status:  fiber.StatusBadRequest,
+		},
+%[6]s		{
+			name:   "create conflict",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			body:   validBody,
+			service: &mocks.%[2]sServiceMock{
+				CreateFunc: func(ctx context.Context, %[1]s *model.%[2]s) error {
+					return gorm.ErrDuplicatedKey
+				},
+			},
+			wantCall: "Create",
+			status:   fiber.StatusConflict,
+		},
+		{
+			name:   "create service error",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			body:   validBody,
+			service: &mocks.%[2]sServiceMock{
+				CreateFunc: func(ctx context.Context, %[1]s *model.%[2]s) error {
+					return errService
+				},
+			},
+			wantCall: "Create",
+			status:   fiber.StatusInternalServerError,
+		},
+`, variable, structName, fiberMethod(op.Method), op.Path, fiberStatus(op.Status), invalidBodyCase("create", op.Method, fmt.Sprintf("%q", op.Path), structName, spec)))
+	}
+	if op := routes.Update; op != nil {
+		cases.WriteString(fmt.Sprintf(`		{
+			name:     "update",
+			method:   http.Method%[3]s,
+			path:     route + %[4]q,
+			body:     validBody,
+			service:  &mocks.%[2]sServiceMock{},
+			wantCall: "Update",
+			status:   %[6]s,
+		},
+		{
+			name:    "update invalid id",
+			method:  http.Method%[3]s,
+			path:    route + %[5]q,
+			body:    validBody,
+			service: &mocks.%[2]sServiceMock{},
+			status:  fiber.StatusBadRequest,
+		},
+		{
+			name:    "update bad body",
+			method:  http.Method%[3]s,
+			path:    route + %[4]q,
+			body:    "{",
+			service: &mocks.%[2]sServiceMock{},
+			status:  fiber.StatusBadRequest,
+		},
+%[7]s		{
+			name:   "update not found",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			body:   validBody,
+			service: &mocks.%[2]sServiceMock{
+				UpdateFunc: func(ctx context.Context, id %[8]s, %[1]s *model.%[2]s) error {
+					return gorm.ErrRecordNotFound
+				},
+			},
+			wantCall: "Update",
+			status:   fiber.StatusNotFound,
+		},
+		{
+			name:   "update conflict",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			body:   validBody,
+			service: &mocks.%[2]sServiceMock{
+				UpdateFunc: func(ctx context.Context, id %[8]s, %[1]s *model.%[2]s) error {
+					return gorm.ErrDuplicatedKey
+				},
+			},
+			wantCall: "Update",
+			status:   fiber.StatusConflict,
+		},
+		{
+			name:   "update service error",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			body:   validBody,
+			service: &mocks.%[2]sServiceMock{
+				UpdateFunc: func(ctx context.Context, id %[8]s, %[1]s *model.%[2]s) error {
+					return errService
+				},
+			},
+			wantCall: "Update",
+			status:   fiber.StatusInternalServerError,
+		},
+`, variable, structName, fiberMethod(op.Method), op.TestPath(key.TestID), op.TestPath("abc"), fiberStatus(op.Status),
+			invalidBodyCase("update", op.Method, fmt.Sprintf("%q", op.TestPath(key.TestID)), structName, spec), key.GoType))
+	}
+	if op := routes.Delete; op != nil {
+		cases.WriteString(fmt.Sprintf(`		{
+			name:     "delete",
+			method:   http.Method%[3]s,
+			path:     route + %[4]q,
+			service:  &mocks.%[2]sServiceMock{},
+			wantCall: "Delete",
+			status:   %[6]s,
+		},
+		{
+			name:    "delete invalid id",
+			method:  http.Method%[3]s,
+			path:    route + %[5]q,
+			service: &mocks.%[2]sServiceMock{},
+			status:  fiber.StatusBadRequest,
+		},
+		{
+			name:   "delete not found",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			service: &mocks.%[2]sServiceMock{
+				DeleteFunc: func(ctx context.Context, id %[7]s) error {
+					return gorm.ErrRecordNotFound
+				},
+			},
+			wantCall: "Delete",
+			status:   fiber.StatusNotFound,
+		},
+		{
+			name:   "delete service error",
+			method: http.Method%[3]s,
+			path:   route + %[4]q,
+			service: &mocks.%[2]sServiceMock{
+				DeleteFunc: func(ctx context.Context, id %[7]s) error {
+					return errService
+				},
+			},
+			wantCall: "Delete",
+			status:   fiber.StatusInternalServerError,
+		},
+`, variable, structName, fiberMethod(op.Method), op.TestPath(key.TestID), op.TestPath("abc"), fiberStatus(op.Status), key.GoType))
+	}
+
+	// The body constant is only declared when a create or update route sends it
+	validBody := ""
+	if routes.Create != nil || routes.Update != nil {
+		validBody = fmt.Sprintf("\tvalidBody := %s\n", strconv.Quote(sampleRequestBody(spec)))
+	}
+
+	// The mock function types of the cases decide which packages are imported
+	var unusedImports []string
+	for _, pkg := range []string{"context", "model", "gorm"} {
+		if !strings.Contains(cases.String()+softDeleteCases, pkg+".") {
+			unusedImports = append(unusedImports, pkg)
+		}
+	}
+	content := fmt.Sprintf(`package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"%[1]s/internal/app/adapter/handler"
+	"%[1]s/internal/app/domain"
+	"%[1]s/internal/app/domain/mocks"
+	"%[1]s/internal/app/domain/model"
+	"%[1]s/internal/infra/variables"
+
+	"github.com/gofiber/fiber/v2"
+%[7]s	"gorm.io/gorm"
+)
+
+func Test%[2]sHandler(t *testing.T) {
+	route := variables.PrefixRoute()
+%[3]s%[6]s	errService := errors.New("service error")
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		service  *mocks.%[2]sServiceMock
+		wantCall string
+		status   int
+	}{
+%[4]s%[5]s	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			handler.New%[2]sHandler(&domain.Services{%[2]sService: tt.service}).Configure(app)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %%v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("expected status %%d, got %%d", tt.status, resp.StatusCode)
+			}
+
+			calls := tt.service.Calls()
+			if tt.wantCall == "" && len(calls) != 0 {
+				t.Errorf("expected no service calls, got %%v", calls)
+			}
+			if tt.wantCall != "" && len(tt.service.CallsTo(tt.wantCall)) != 1 {
+				t.Errorf("expected one call to %%s, got %%v", tt.wantCall, calls)
+			}
+		})
+	}
 }
-`,
-		currentFolderName,
-		currentFolderName,
-		currentFolderName,
-		currentFolderName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		modelName,
-		modelName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName, //findall
-		structName,
-		structName,
-		structName,
-		utils.ToUrlCase(modelName),
-		structName,
-		structName,
-		modelName,
-		structName,
-		modelName,  //findall
-		structName, //findby
-		structName,
-		structName,
-		structName,
-		structName,
-		utils.ToUrlCase(modelName),
-		structName,
-		structName,
-		modelName,
-		structName,
-		structName,
-		modelName,  //findby
-		structName, //create
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		utils.ToUrlCase(modelName),
-		structName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		modelName,  //create
-		structName, //initial update
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		utils.ToUrlCase(modelName),
-		structName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		modelName,
-		structName,
-		modelName,
-		modelName,
-		modelName,  //finish update
-		structName, //delete
-		structName,
-		structName,
-		structName,
-		utils.ToUrlCase(modelName),
-		structName,
-		structName,
-		structName)
+`, currentFolderName, structName, softDeleteRoute, cases.String(), softDeleteCases, validBody, key.ImportLine(cases.String()+softDeleteCases))
+	for _, unused := range unusedImports {
+		content = removeImportLine(content, unused)
+	}
 
 	_, err = file.WriteString(content)
 	return err
 }
 
+// removeImportLine drops the import of the package named name from a generated file.
+func removeImportLine(content, name string) string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, `"`) && strings.HasSuffix(trimmed, `"`) && filepath.Base(strings.Trim(trimmed, `"`)) == name {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// invalidBodyCase returns the test case sending an empty body, which fails validation
+// when the model has required fields.
+func invalidBodyCase(operation, method, path, structName string, spec ModelSpec) string {
+	if !hasRequiredFields(spec.inboundFields()) {
+		return ""
+	}
+	return fmt.Sprintf(`		{
+			name:    "%[1]s invalid body",
+			method:  http.Method%[2]s,
+			path:    route + %[3]s,
+			body:    "{}",
+			service: &mocks.%[4]sServiceMock{},
+			status:  fiber.StatusBadRequest,
+		},
+`, operation, fiberMethod(method), path, structName)
+}
+
 // addLineToNewHandlersBlock adds a line inside the `return &Handlers{}` block in the `NewHandlers` function.
 // Handles both empty and non-empty blocks.
 func addLineToNewHandlersBlock(lines []string, newLine string) []string {
@@ -337,11 +955,15 @@ func updateHandlersFile(handlersFilePath, modelName, structName, currentFolderNa
 
 	// Add initialization of the handler in NewHandlers
 	handlerInit := fmt.Sprintf("\t\t%sHandler: handler.New%sHandler(services),", modelName, structName)
-	lines = addLineToNewHandlersBlock(lines, handlerInit)
+	if !strings.Contains(string(content), handlerInit) {
+		lines = addLineToNewHandlersBlock(lines, handlerInit)
+	}
 
 	// Add Configure call in Handlers.Configure
 	configureCall := fmt.Sprintf("\th.%sHandler.Configure(server)", modelName)
-	lines = insertLineHandlerAfter(lines, "func (h *Handlers) Configure(server *fiber.App) {", configureCall)
+	if !strings.Contains(string(content), configureCall) {
+		lines = insertLineHandlerAfter(lines, "func (h *Handlers) Configure(server *fiber.App) {", configureCall)
+	}
 
 	// Write the updated content back to handlers.go
 	return os.WriteFile(handlersFilePath, []byte(strings.Join(lines, "\n")), 0644)
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+)
+
+// generatedColumns are the columns of the fields every model declares.
+var generatedColumns = map[string]string{
+	"id":         "ID",
+	"created_at": "CreatedAt",
+	"updated_at": "UpdatedAt",
+	"deleted_at": "DeletedAt",
+}
+
+// sqlReservedWords are the words reserved by PostgreSQL, MySQL or SQLite that fit a table or column name.
+var sqlReservedWords = map[string]bool{}
+
+// generatedIdentifiers are the packages and local names of the generated layers, which the variables
+// named after a model would shadow or redeclare. Those variables are renamed by modelVariable.
+var generatedIdentifiers = map[string]bool{}
+
+func init() {
+	for _, name := range strings.Fields(`context database datatypes decimal domain errors factory fake fiber fmt gorm
+		handler http httptest inbound json logger mapper mocks model outbound pq presenter reflect repository
+		slices sqlite strconv strings swagger sync testing time ulid uuid variables adminRoute app args c call
+		calls count ctx db dbs deleted err errService existing f found h i id input kept m method n ok override r
+		req resp result route s server serviceRoute services sqlDB t tests translator trashed tt validBody`) {
+		generatedIdentifiers[name] = true
+	}
+	for _, word := range strings.Fields(`all and any as asc between both by case cast check column constraint create cross
+		current_date current_time current_timestamp current_user default delete desc distinct drop else end except
+		exists false fetch for foreign from full grant group having in index inner insert intersect into is join key
+		leading left like limit natural not null offset on only or order outer primary references right select
+		session_user set table then to trailing true union unique update user using values when where window with`) {
+		sqlReservedWords[word] = true
+	}
+}
+
+// modelVariable returns the name of the variables holding a model, or its records when name is plural, in the
+// generated code. Names the generated code already uses get a Record suffix, like inputRecord for Input.
+func modelVariable(name string) string {
+	variable := inflection.Camelize(name)
+	if generatedIdentifiers[variable] {
+		return variable + "Record"
+	}
+	return variable
+}
+
+// validateModelName checks that a model name gives valid variables, and suggests a name that does.
+// Its table is quoted by the migrations and GORM, so reserved SQL words like user are accepted.
+func validateModelName(name string) error {
+	if err := validateIdentifier(name, inflection.Camelize); err != nil {
+		return fmt.Errorf("invalid model name %s: %v", name, err)
+	}
+
+	// The name is used as is for variables, like type := model.Type{}
+	variable := inflection.Camelize(name)
+	for _, identifier := range []string{variable, inflection.Camelize(inflection.Pluralize(name))} {
+		if reason := reservedIdentifier(identifier); reason != "" {
+			return fmt.Errorf("invalid model name %s: %s is %s, try a more specific name like %s", name, identifier, reason, variable+"Record")
+		}
+	}
+	return nil
+}
+
+// validateFieldName checks that an attribute name of a model gives a valid field and column, and that
+// neither collides with the fields generated for every model or declared before it.
+func validateFieldName(structName, name string, fields []ModelField) error {
+	if err := validateIdentifier(name, inflection.Pascalize); err != nil {
+		return fmt.Errorf("invalid field name %s: %v", name, err)
+	}
+
+	fieldName := inflection.Pascalize(name)
+	suggestion := structName + fieldName
+	if reason := reservedIdentifier(inflection.Camelize(name)); reason != "" {
+		return fmt.Errorf("invalid field name %s: %s is %s, try a more specific name like %s", name, inflection.Camelize(name), reason, suggestion)
+	}
+
+	column := toColumnName(fieldName)
+	if sqlReservedWords[column] {
+		return fmt.Errorf("invalid field name %s: its column %s is a reserved SQL word, try a more specific name like %s", name, column, suggestion)
+	}
+	if generated, ok := generatedColumns[column]; ok {
+		return fmt.Errorf("invalid field name %s: every model already declares %s", name, generated)
+	}
+
+	if declared := declaredField(fields, fieldName); declared != "" {
+		return fmt.Errorf("invalid field name %s: %s is already declared", name, declared)
+	}
+	return nil
+}
+
+// validateRelationName checks the name of a related model, and that its foreign key and the field
+// holding it do not collide with the fields declared before them.
+func validateRelationName(structName, name string, fields []ModelField) error {
+	if err := validateIdentifier(name, inflection.Pascalize); err != nil {
+		return fmt.Errorf("invalid model name %s: %v", name, err)
+	}
+
+	relationName := inflection.Pascalize(name)
+	if declared := declaredField(fields, relationName); declared != "" {
+		return fmt.Errorf("invalid relationship %s: %s is already declared", name, declared)
+	}
+	return validateFieldName(structName, relationName+"Id", fields)
+}
+
+// declaredField returns the field, or the field holding a related model, already using a name or its column.
+func declaredField(fields []ModelField, name string) string {
+	for _, field := range fields {
+		names := []string{field.Name}
+		if field.Relation != "" {
+			names = append(names, field.RelationField())
+		}
+		for _, declared := range names {
+			if declared == name || toColumnName(declared) == toColumnName(name) {
+				return declared
+			}
+		}
+	}
+	return ""
+}
+
+// validateIdentifier checks that a name is a Go identifier, and suggests the spelling given by
+// format when the name only has separators or punctuation in it.
+func validateIdentifier(name string, format func(string) string) error {
+	if name == "" {
+		return fmt.Errorf("the name is empty")
+	}
+	// Keywords are reported by reservedIdentifier, with a suggestion
+	if token.IsIdentifier(name) || token.IsKeyword(name) {
+		return nil
+	}
+
+	suggestion := format(strings.Join(inflection.Words(name), " "))
+	if token.IsIdentifier(suggestion) {
+		return fmt.Errorf("only letters, digits and underscores are allowed, try %s", suggestion)
+	}
+	return fmt.Errorf("names must start with a letter and hold only letters, digits and underscores")
+}
+
+// reservedIdentifier describes why a variable name cannot be used in the generated code,
+// or returns an empty string when it can.
+func reservedIdentifier(name string) string {
+	switch {
+	case token.IsKeyword(name):
+		return "a Go keyword"
+	case types.Universe.Lookup(name) != nil:
+		return "a predeclared Go identifier"
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package commands
+
+import "testing"
+
+func TestValidateModelName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "Post"},
+		{name: "orderItem"},
+		// Tables are quoted, and the variables named like the generated code get a suffix
+		{name: "User"},
+		{name: "Order"},
+		{name: "Group"},
+		{name: "Input"},
+		{name: "ctx"},
+		{name: "T"},
+		{name: "order-item", wantErr: true},
+		{name: "1post", wantErr: true},
+		{name: "type", wantErr: true},
+		{name: "error", wantErr: true},
+		{name: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateModelName(tt.name); (err != nil) != tt.wantErr {
+				t.Errorf("validateModelName(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModelVariable(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Post", "post"},
+		{"OrderItems", "orderItems"},
+		{"User", "user"},
+		{"Input", "inputRecord"},
+		{"Ctx", "ctxRecord"},
+		{"Mapper", "mapperRecord"},
+		{"Dbs", "dbsRecord"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := modelVariable(tt.name); got != tt.want {
+				t.Errorf("modelVariable(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
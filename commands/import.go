@@ -0,0 +1,293 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// ImportDDL generates the models and layers of every table created in a SQL script.
+func ImportDDL(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	tables, err := ParseDDL(string(content))
+	if err != nil {
+		return err
+	}
+	return importTables(tables)
+}
+
+// ImportSQLite generates the models and layers of every table of a SQLite database file.
+func ImportSQLite(path string) error {
+	ddl, err := ReadSQLiteDDL(path)
+	if err != nil {
+		return err
+	}
+
+	tables, err := ParseDDL(ddl)
+	if err != nil {
+		return err
+	}
+	return importTables(tables)
+}
+
+// importTables generates a model for each table and records the tables in the schema snapshot.
+// The tables already exist, so no migration is written for them.
+func importTables(tables []DDLTable) error {
+	if len(tables) == 0 {
+		return fmt.Errorf("no CREATE TABLE statement found")
+	}
+
+	// Foreign keys only become relationships when the referenced table has a model, and get its key type
+	structNames := map[string]string{}
+	tableKeys := map[string]string{}
+	for _, table := range tables {
+		if _, key, err := tablePrimaryKey(table); err == nil && validateModelName(tableStructName(table.Name)) == nil {
+			structNames[table.Name] = tableStructName(table.Name)
+			tableKeys[table.Name] = key
+		}
+	}
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+	for _, model := range models {
+		if model.TableName != "" {
+			structNames[model.TableName] = model.Name
+		}
+	}
+
+	var imported []string
+	for _, table := range tables {
+		spec, options, err := tableModelSpec(table, structNames, tableKeys)
+		if err != nil {
+			fmt.Printf("Skipping table %s: %v\n", table.Name, err)
+			continue
+		}
+
+		modelName := utils.ToCamelCase(spec.Name)
+		if _, err := os.Stat(filepath.Join(modelsDomainPath, modelName+".go")); err == nil {
+			fmt.Printf("Skipping table %s: model %s already exists\n", table.Name, spec.Name)
+			continue
+		}
+
+		if err := generateModelLayers(modelName, spec, options); err != nil {
+			return fmt.Errorf("error generating model for table %s: %v", table.Name, err)
+		}
+		imported = append(imported, table.Name)
+	}
+
+	if len(imported) == 0 {
+		return nil
+	}
+	return recordImportedTables(imported)
+}
+
+// tableModelSpec maps a table to the model spec and options used to generate it.
+// The table needs a single integer or UUID primary key, which becomes the ID field, and a name that gives
+// a valid model name. Sizes, precisions, unique constraints and indexes are kept, and foreign keys get the
+// type of the key they reference, looked up in tableKeys for the imported tables.
+func tableModelSpec(table DDLTable, structNames, tableKeys map[string]string) (ModelSpec, ModelOptions, error) {
+	spec := ModelSpec{Name: structNames[table.Name], TableName: table.Name}
+	options := ModelOptions{}
+
+	primaryKey, key, err := tablePrimaryKey(table)
+	if err != nil {
+		return spec, options, err
+	}
+	if err := validateModelName(tableStructName(table.Name)); err != nil {
+		return spec, options, err
+	}
+	spec.IDColumn = primaryKey.Name
+	options.Key = key
+
+	// Timestamps and soft delete are only generated when the table has their columns
+	createdAt, updatedAt, deletedAt := table.Column("created_at"), table.Column("updated_at"), table.Column("deleted_at")
+	spec.Timestamps = createdAt != nil && updatedAt != nil &&
+		ddlGoType(createdAt.Type) == "time.Time" && ddlGoType(updatedAt.Type) == "time.Time"
+	options.SoftDelete = deletedAt != nil && ddlGoType(deletedAt.Type) == "time.Time"
+
+	for _, column := range table.Columns {
+		name := strings.ToLower(column.Name)
+		if column.PrimaryKey ||
+			(spec.Timestamps && (name == "created_at" || name == "updated_at")) ||
+			(options.SoftDelete && name == "deleted_at") {
+			continue
+		}
+
+		field := ModelField{
+			Name:        columnFieldName(column.Name),
+			Type:        ddlGoType(column.Type),
+			Nullable:    !column.NotNull,
+			Default:     column.Default,
+			Description: singleLine(column.Comment),
+		}
+		if field.Type == "bool" {
+			// MySQL and SQLite store booleans as 0 and 1
+			switch field.Default {
+			case "0":
+				field.Default = "false"
+			case "1":
+				field.Default = "true"
+			}
+		}
+		// Columns the generated code cannot name a field after keep their column under the suggested name.
+		// The timestamps left are not time columns, so the model does not declare them.
+		timestamp := name == "created_at" || name == "updated_at" || name == "deleted_at"
+		if err := validateFieldName(spec.Name, field.Name, spec.Fields); err != nil && !timestamp {
+			renamed := spec.Name + field.Name
+			if validateFieldName(spec.Name, renamed, spec.Fields) != nil {
+				fmt.Printf("Skipping column %s.%s: %v\n", table.Name, column.Name, err)
+				continue
+			}
+			fmt.Printf("Naming the field of column %s.%s %s: %v\n", table.Name, column.Name, renamed, err)
+			field.Name = renamed
+		}
+		if field.ColumnName() != column.Name {
+			field.Column = column.Name
+		}
+		if related, ok := structNames[column.References]; ok && strings.HasSuffix(field.Name, "Id") {
+			field.Relation = related
+			field.Type = relationKeyType(related, ModelOptions{Key: tableKeys[column.References]}.key())
+		}
+
+		arguments := ddlTypeArguments(column.Type)
+		switch {
+		case field.Type == "string" && len(arguments) == 1:
+			field.Size = arguments[0]
+		case field.Type == "decimal.Decimal" && len(arguments) == 2:
+			field.Precision, field.Scale = arguments[0], arguments[1]
+		case field.Type == "decimal.Decimal" && len(arguments) == 1:
+			field.Precision = arguments[0]
+		}
+		field.Unique = column.Unique
+		spec.Fields = append(spec.Fields, field)
+	}
+
+	applyTableIndexes(&spec, table)
+	for i := range spec.Fields {
+		field := &spec.Fields[i]
+		if err := validateColumnOptions(field); err != nil {
+			fmt.Printf("Skipping the size, precision and index of %s.%s: %v\n", table.Name, field.Name, err)
+			field.Size, field.Precision, field.Scale, field.Index = 0, 0, 0, ""
+		}
+	}
+	return spec, options, nil
+}
+
+// applyTableIndexes marks the fields of the columns of the indexes of a table as unique or indexed.
+// Indexes of several columns become composite indexes, named after the table and their columns when
+// they have no name, and the ones on columns without a field, like the ID, are left out.
+func applyTableIndexes(spec *ModelSpec, table DDLTable) {
+	for _, index := range table.Indexes {
+		var fields []*ModelField
+		for _, column := range index.Columns {
+			for i := range spec.Fields {
+				if strings.EqualFold(spec.Fields[i].ColumnName(), column) {
+					fields = append(fields, &spec.Fields[i])
+				}
+			}
+		}
+		if len(fields) == 0 || len(fields) != len(index.Columns) {
+			continue
+		}
+
+		if len(fields) == 1 {
+			if index.Unique {
+				fields[0].Unique, fields[0].Indexed = true, false
+			} else if !fields[0].Unique {
+				fields[0].Indexed = true
+			}
+			continue
+		}
+		// A field is part of a single composite index, and cannot be unique on its own too
+		if slices.ContainsFunc(fields, func(field *ModelField) bool { return field.Index != "" || field.Unique }) {
+			fmt.Printf("Skipping index %s of %s: its columns are already unique or part of another index\n", index.Name, table.Name)
+			continue
+		}
+		name := index.Name
+		if name == "" {
+			name = "idx_" + table.Name + "_" + strings.Join(index.Columns, "_")
+		}
+		for _, field := range fields {
+			field.Index, field.Unique, field.Indexed = name, index.Unique, false
+		}
+	}
+}
+
+// tablePrimaryKey returns the primary key column of a table, which has to be a single column, and the
+// name of its key type: uuid for uuid and char(36) columns, ulid for char(26) ones, int64 for bigint ones
+// and uint for the other integers.
+func tablePrimaryKey(table DDLTable) (*DDLColumn, string, error) {
+	var primaryKeys []DDLColumn
+	for _, column := range table.Columns {
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, column)
+		}
+	}
+	if len(primaryKeys) != 1 {
+		return nil, "", fmt.Errorf("the table needs a single primary key")
+	}
+
+	primaryKey := &primaryKeys[0]
+	goType, arguments := ddlGoType(primaryKey.Type), ddlTypeArguments(primaryKey.Type)
+	switch {
+	case goType == "uuid.UUID", goType == "string" && slices.Equal(arguments, []int{36}):
+		return primaryKey, "uuid", nil
+	case goType == "string" && slices.Equal(arguments, []int{26}):
+		return primaryKey, "ulid", nil
+	case goType == "int64":
+		return primaryKey, "int64", nil
+	case isIntegerType(goType):
+		return primaryKey, "uint", nil
+	}
+	return nil, "", fmt.Errorf("the primary key %s has to be an integer, uuid, char(36) or char(26) column", primaryKey.Name)
+}
+
+// recordImportedTables adds the imported tables to the schema snapshot, so the next
+// migration only contains the changes made to their models afterwards.
+func recordImportedTables(tables []string) error {
+	current, err := ReadModelSchema(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+
+	snapshot, err := loadSchemaSnapshot()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range tables {
+		table := current.Table(name)
+		if table == nil {
+			continue
+		}
+		if existing := snapshot.Table(name); existing != nil {
+			*existing = *table
+		} else {
+			snapshot.Tables = append(snapshot.Tables, *table)
+		}
+	}
+
+	sort.Slice(snapshot.Tables, func(i, j int) bool { return snapshot.Tables[i].Name < snapshot.Tables[j].Name })
+	return saveSchemaSnapshot(snapshot)
+}
+
+// tableStructName derives a model struct name from a table name: "order_items" gives "OrderItem".
+func tableStructName(table string) string {
+	return inflection.Pascalize(inflection.Singularize(table))
+}
+
+// columnFieldName derives a Go field name from a column name: "author_id" gives "AuthorId".
+func columnFieldName(column string) string {
+	return inflection.Pascalize(column)
+}
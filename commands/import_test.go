@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newTestProject copies the project skeleton of testdata/project to a temporary directory and makes it
+// the working directory, as the generators write to the current project.
+func newTestProject(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("generating and testing a project is skipped in short mode")
+	}
+
+	dir := filepath.Join(t.TempDir(), "project")
+	if err := os.CopyFS(dir, os.DirFS(filepath.Join("testdata", "project"))); err != nil {
+		t.Fatal(err)
+	}
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(previous) })
+	return dir
+}
+
+// testGeneratedProject resolves the dependencies of a generated project, skipping the test when they
+// cannot be downloaded, then vets it and runs its generated tests.
+func testGeneratedProject(t *testing.T, dir string) {
+	t.Helper()
+	run := func(args ...string) ([]byte, error) {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		return cmd.CombinedOutput()
+	}
+	if output, err := run("mod", "tidy"); err != nil {
+		t.Skipf("the dependencies of the generated project are not available: %v\n%s", err, output)
+	}
+	if output, err := run("vet", "./..."); err != nil {
+		t.Fatalf("go vet of the generated project failed: %v\n%s", err, output)
+	}
+	if output, err := run("test", "./internal/app/..."); err != nil {
+		t.Fatalf("the tests of the generated project failed: %v\n%s", err, output)
+	}
+}
+
+// TestImportDDLPrimaryKeyColumn imports tables whose primary key is not named id, with soft delete to
+// cover the restore and purge queries, and a foreign key to a UUID key declared after it, then runs the
+// tests of the generated project.
+func TestImportDDLPrimaryKeyColumn(t *testing.T) {
+	dir := newTestProject(t)
+	schema := filepath.Join(dir, "schema.sql")
+	err := os.WriteFile(schema, []byte(`
+CREATE TABLE orders (
+  order_id BIGSERIAL PRIMARY KEY,
+  customer_id UUID NOT NULL REFERENCES customers(customer_id),
+  note TEXT,
+  created_at TIMESTAMP NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  deleted_at TIMESTAMP
+);
+CREATE TABLE customers (customer_id UUID PRIMARY KEY DEFAULT gen_random_uuid(), name VARCHAR(80) NOT NULL);
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ImportDDL(schema); err != nil {
+		t.Fatalf("ImportDDL() error = %v", err)
+	}
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, err := FindModel(models, "Order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range order.Fields {
+		if len(field.Names) > 0 && field.Names[0].Name == "CustomerId" && types.ExprString(field.Type) != "uuid.UUID" {
+			t.Errorf("Order.CustomerId has type %s, want uuid.UUID", types.ExprString(field.Type))
+		}
+	}
+	testGeneratedProject(t, dir)
+}
+
+// TestImportDDLNames imports tables and columns named like SQL words or the generated code: tables are
+// quoted, the columns keep their name under a field prefixed with the model, and tables giving no
+// valid model name are skipped.
+func TestImportDDLNames(t *testing.T) {
+	dir := newTestProject(t)
+	schema := filepath.Join(dir, "schema.sql")
+	err := os.WriteFile(schema, []byte(`
+CREATE TABLE users (id INTEGER PRIMARY KEY, "order" INTEGER NOT NULL, "type" TEXT NOT NULL);
+CREATE TABLE inputs (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users(id), "group" TEXT);
+CREATE TABLE types (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ImportDDL(schema); err != nil {
+		t.Fatalf("ImportDDL() error = %v", err)
+	}
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindModel(models, "Type"); err == nil {
+		t.Error("the types table was imported, want it skipped")
+	}
+	want := map[string][]string{"User": {"UserOrder", "UserType"}, "Input": {"UserId", "User", "InputGroup"}}
+	for name, fields := range want {
+		model, err := FindModel(models, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, field := range model.Fields {
+			for _, fieldName := range field.Names {
+				got = append(got, fieldName.Name)
+			}
+		}
+		for _, field := range fields {
+			if !slices.Contains(got, field) {
+				t.Errorf("%s fields = %v, want %s", name, got, field)
+			}
+		}
+	}
+	testGeneratedProject(t, dir)
+}
+
+// TestImportOpenAPINames imports schemas and properties named like SQL words or the generated code: the
+// properties keep their JSON key under a field prefixed with the model, and schemas giving no valid model
+// name are skipped with the references to them.
+func TestImportOpenAPINames(t *testing.T) {
+	dir := newTestProject(t)
+	document := filepath.Join(dir, "openapi.yaml")
+	err := os.WriteFile(document, []byte(`
+openapi: 3.0.0
+paths:
+  /orders:
+    post:
+      responses:
+        "201": {content: {application/json: {schema: {$ref: "#/components/schemas/Order"}}}}
+  /types:
+    post:
+      responses:
+        "201": {content: {application/json: {schema: {$ref: "#/components/schemas/Type"}}}}
+components:
+  schemas:
+    Order:
+      properties:
+        id: {type: integer}
+        group: {type: string}
+        type: {type: string, enum: [retail, wholesale]}
+        kind: {$ref: "#/components/schemas/Type"}
+    Type:
+      properties:
+        id: {type: integer}
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ImportOpenAPI(document); err != nil {
+		t.Fatalf("ImportOpenAPI() error = %v", err)
+	}
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindModel(models, "Type"); err == nil {
+		t.Error("the Type schema was imported, want it skipped")
+	}
+	order, err := FindModel(models, "Order")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, field := range order.Fields {
+		for _, name := range field.Names {
+			got = append(got, name.Name+" "+field.Tag.Value)
+		}
+	}
+	for _, want := range []string{`OrderGroup`, `json:"group`, `OrderType`, `json:"type`} {
+		if !slices.ContainsFunc(got, func(field string) bool { return strings.Contains(field, want) }) {
+			t.Errorf("Order fields = %q, want %s", got, want)
+		}
+	}
+	if slices.ContainsFunc(got, func(field string) bool { return strings.HasPrefix(field, "Kind") }) {
+		t.Errorf("Order fields = %q, want the reference to Type skipped", got)
+	}
+	testGeneratedProject(t, dir)
+}
+
+func TestTablePrimaryKey(t *testing.T) {
+	tests := []struct {
+		statement string
+		column    string
+		key       string
+		wantErr   bool
+	}{
+		{statement: "CREATE TABLE t (id INTEGER PRIMARY KEY AUTOINCREMENT)", column: "id", key: "uint"},
+		{statement: "CREATE TABLE t (id SERIAL PRIMARY KEY)", column: "id", key: "uint"},
+		{statement: "CREATE TABLE t (order_id BIGSERIAL PRIMARY KEY)", column: "order_id", key: "int64"},
+		{statement: "CREATE TABLE t (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id))", column: "id", key: "uint"},
+		{statement: "CREATE TABLE t (id UUID PRIMARY KEY DEFAULT gen_random_uuid())", column: "id", key: "uuid"},
+		{statement: "CREATE TABLE t (id CHAR(36) NOT NULL, PRIMARY KEY (id))", column: "id", key: "uuid"},
+		{statement: "CREATE TABLE t (id CHAR(26) PRIMARY KEY)", column: "id", key: "ulid"},
+		{statement: "CREATE TABLE t (code VARCHAR(10) PRIMARY KEY)", wantErr: true},
+		{statement: "CREATE TABLE t (a INTEGER, b INTEGER, PRIMARY KEY (a, b))", wantErr: true},
+		{statement: "CREATE TABLE t (name TEXT)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			tables, err := ParseDDL(tt.statement)
+			if err != nil || len(tables) != 1 {
+				t.Fatalf("ParseDDL() = %v, %v", tables, err)
+			}
+			column, key, err := tablePrimaryKey(tables[0])
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("tablePrimaryKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (column.Name != tt.column || key != tt.key) {
+				t.Errorf("tablePrimaryKey() = %s, %s, want %s, %s", column.Name, key, tt.column, tt.key)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// indexNamePattern matches the names of composite indexes, used as SQL identifiers.
+var indexNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// indexSettings returns the gorm settings indexing a field: "unique" and "index" for the column alone,
+// "uniqueIndex:<name>" and "index:<name>" for a composite index shared with other fields.
+func indexSettings(field ModelField) []string {
+	switch {
+	case field.Index != "" && field.Unique:
+		return []string{"uniqueIndex:" + field.Index}
+	case field.Index != "":
+		return []string{"index:" + field.Index}
+	case field.Unique:
+		return []string{"unique"}
+	case field.Indexed:
+		return []string{"index"}
+	}
+	return nil
+}
+
+// precisionType returns a numeric SQL type, like "numeric(20,8)", with the given precision and scale.
+// It reports false for the types that are not numeric.
+func precisionType(sqlType string, precision, scale int) (string, bool) {
+	base, _, _ := strings.Cut(sqlType, "(")
+	switch strings.ToLower(base) {
+	case "numeric", "decimal":
+		return fmt.Sprintf("%s(%d,%d)", base, precision, scale), true
+	}
+	return sqlType, false
+}
+
+// validateColumnOptions checks the index, size, precision and default length of a field, and makes its size
+// the maximum length of the inbound value when it has none.
+func validateColumnOptions(field *ModelField) error {
+	if field.Index != "" && !indexNamePattern.MatchString(field.Index) {
+		return fmt.Errorf("invalid index name %q, use letters, digits and underscores", field.Index)
+	}
+	if field.Size < 0 || (field.Size > 0 && (field.Type != "string" || field.Enum)) {
+		return fmt.Errorf("a size can only be given to string fields, and has to be positive")
+	}
+	if field.Size > 0 && !isDefaultExpression(field.Type, field.Default) && utf8.RuneCountInString(field.Default) > field.Size {
+		return fmt.Errorf("the default of %s is longer than its maximum length %d", field.Name, field.Size)
+	}
+	if field.Precision != 0 || field.Scale != 0 {
+		fieldType, _ := LookupFieldType(field.Type)
+		if _, ok := precisionType(fieldType.SQLTypes[projectDialect()], field.Precision, field.Scale); !ok {
+			return fmt.Errorf("a precision can only be given to decimal fields")
+		}
+		if field.Precision <= 0 || field.Scale < 0 || field.Scale > field.Precision {
+			return fmt.Errorf("invalid precision %d and scale %d, the scale has to be between 0 and the precision", field.Precision, field.Scale)
+		}
+	}
+	if field.Size > 0 && field.Rules.MaxLength == nil {
+		size := field.Size
+		field.Rules.MaxLength = &size
+	}
+	return nil
+}
+
+// uniqueTestValue returns the expression of the value a unique field gets in the record numbered n of the
+// repository tests, the same for the same n, or an empty string for the fields left empty.
+func uniqueTestValue(field ModelField) string {
+	if field.Nullable || field.Enum {
+		return ""
+	}
+	switch {
+	case field.Type == "string":
+		return fmt.Sprintf(`fmt.Sprintf("%s-%%d", n)`, field.JSONName())
+	case field.Type == "int":
+		return "n"
+	case isIntegerType(field.Type), field.Type == "float32", field.Type == "float64":
+		return field.Type + "(n)"
+	case field.Type == "time.Time":
+		return "time.Unix(int64(n), 0)"
+	case field.Type == "uuid.UUID":
+		return "uuid.UUID{15: byte(n)}"
+	case field.Type == "decimal.Decimal":
+		return "decimal.NewFromInt(int64(n))"
+	}
+	return ""
+}
+
+// uniqueTestFields returns the fields of the records of the repository tests that have to differ between
+// records, as "Name: value" elements, and reports whether records with the same number conflict.
+func uniqueTestFields(fields []ModelField) ([]string, bool) {
+	// Composite indexes are unique when one of their fields says so
+	uniqueIndexes := map[string]bool{}
+	for _, field := range fields {
+		if field.Index != "" && field.Unique {
+			uniqueIndexes[field.Index] = true
+		}
+	}
+
+	var elements []string
+	conflicts := false
+	filled := map[string]bool{}
+	for _, field := range fields {
+		unique := field.Unique
+		if field.Index != "" {
+			unique = uniqueIndexes[field.Index]
+		}
+		if !unique {
+			continue
+		}
+		value := uniqueTestValue(field)
+		if value == "" {
+			// A composite index with an empty field does not conflict
+			if field.Index != "" {
+				filled[field.Index] = false
+			}
+			continue
+		}
+		elements = append(elements, field.Name+": "+value)
+		if field.Index == "" {
+			conflicts = true
+		} else if _, seen := filled[field.Index]; !seen {
+			filled[field.Index] = true
+		}
+	}
+	for _, ok := range filled {
+		conflicts = conflicts || ok
+	}
+	return elements, conflicts
+}
+
+// repositoryTestModel returns the function building the records of the repository tests of a model,
+// numbered so that the values of their unique fields differ.
+func repositoryTestModel(spec ModelSpec) string {
+	elements, _ := uniqueTestFields(spec.Fields)
+	return fmt.Sprintf(`// new%[1]s returns the %[1]s numbered n, whose unique fields differ from the ones of other numbers.
+func new%[1]s(n int) *model.%[1]s {
+	return &model.%[1]s{%[2]s}
+}
+`, spec.Name, strings.Join(elements, ", "))
+}
@@ -0,0 +1,337 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// jsonObject is a decoded JSON object keeping the order of its keys.
+type jsonObject struct {
+	Keys   []string
+	Values map[string]interface{}
+}
+
+// jsonInference collects the model specs inferred from a JSON payload.
+// Nested objects become belongs-to models and arrays of objects has-many models.
+type jsonInference struct {
+	order  []string
+	specs  map[string]*ModelSpec
+	merged map[string]int // number of objects merged into each spec
+	key    string         // Go type of the foreign keys
+}
+
+// GenerateModelFromJSON generates a model, and the models of its nested objects, from a sample JSON payload.
+// The inferred types are shown to be confirmed or adjusted before any file is written.
+func GenerateModelFromJSON(modelName, path string, options ModelOptions) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	payload, err := decodeOrderedJSON(decoder)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	structName := utils.ToPascalCase(modelName)
+	inference := &jsonInference{specs: map[string]*ModelSpec{}, merged: map[string]int{}, key: options.key().GoType}
+	if err := inference.infer(structName, payload); err != nil {
+		return err
+	}
+
+	for _, name := range inference.order {
+		promptInferredTypes(inference.specs[name])
+	}
+
+	for _, name := range inference.order {
+		spec := *inference.specs[name]
+		fileName := utils.ToCamelCase(spec.Name)
+		if name == structName {
+			fileName = modelName
+		} else if _, err := os.Stat(filepath.Join(modelsDomainPath, fileName+".go")); err == nil {
+			fmt.Printf("Skipping nested model %s: it already exists, make sure it has the fields inferred from the payload\n", name)
+			continue
+		}
+
+		if err := generateModelLayers(fileName, spec, options); err != nil {
+			return fmt.Errorf("error generating model %s: %v", name, err)
+		}
+	}
+
+	// Record the new tables in a versioned migration
+	err = GenerateMigration("create_" + utils.ToSnakeCase(structName))
+	if err != nil {
+		return fmt.Errorf("error generating migration: %v", err)
+	}
+
+	return nil
+}
+
+// decodeOrderedJSON decodes the next JSON value, returning objects as *jsonObject and arrays as []interface{}.
+func decodeOrderedJSON(decoder *json.Decoder) (interface{}, error) {
+	next, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch next {
+	case json.Delim('{'):
+		object := &jsonObject{Values: map[string]interface{}{}}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyToken.(string)
+			value, err := decodeOrderedJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := object.Values[key]; !exists {
+				object.Keys = append(object.Keys, key)
+			}
+			object.Values[key] = value
+		}
+		_, err := decoder.Token()
+		return object, err
+	case json.Delim('['):
+		values := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedJSON(decoder)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		_, err := decoder.Token()
+		return values, err
+	}
+	return next, nil
+}
+
+// jsonObjects returns the value when it is an object, or the objects of an array.
+func jsonObjects(value interface{}) []*jsonObject {
+	switch value := value.(type) {
+	case *jsonObject:
+		return []*jsonObject{value}
+	case []interface{}:
+		var objects []*jsonObject
+		for _, element := range value {
+			if object, ok := element.(*jsonObject); ok {
+				objects = append(objects, object)
+			}
+		}
+		return objects
+	}
+	return nil
+}
+
+// infer merges the objects of value into the spec of the model name.
+func (in *jsonInference) infer(name string, value interface{}) error {
+	objects := jsonObjects(value)
+	if len(objects) == 0 {
+		return fmt.Errorf("the payload of %s is not an object or an array of objects", name)
+	}
+
+	spec, ok := in.specs[name]
+	if !ok {
+		spec = &ModelSpec{Name: name, Timestamps: true}
+		in.specs[name] = spec
+		in.order = append(in.order, name)
+	}
+
+	for _, object := range objects {
+		if err := in.merge(spec, object); err != nil {
+			return err
+		}
+	}
+
+	// Types still unknown only had null values
+	for i := range spec.Fields {
+		if spec.Fields[i].Type == "" {
+			spec.Fields[i].Type = "string"
+		}
+	}
+	return nil
+}
+
+// merge adds the keys of an object to a spec. Fields missing from some of the objects are nullable.
+func (in *jsonInference) merge(spec *ModelSpec, object *jsonObject) error {
+	first := in.merged[spec.Name] == 0
+	in.merged[spec.Name]++
+
+	seen := map[string]bool{}
+	for _, key := range object.Keys {
+		switch strings.ToLower(key) {
+		case "id", "created_at", "createdat", "updated_at", "updatedat", "deleted_at", "deletedat":
+			continue
+		}
+
+		fieldName := columnFieldName(key)
+		if !token.IsIdentifier(fieldName) {
+			fmt.Printf("Skipping key %s.%s: it is not a valid Go field name\n", spec.Name, key)
+			continue
+		}
+
+		switch value := object.Values[key].(type) {
+		case *jsonObject:
+			related := tableStructName(key)
+			if err := in.infer(related, value); err != nil {
+				return err
+			}
+			field := ModelField{Name: fieldName + "Id", Type: in.key, Relation: related}
+			seen[field.Name] = true
+			addInferredField(spec, field, first)
+		case []interface{}:
+			objects := jsonObjects(value)
+			if len(objects) == 0 || len(objects) != len(value) {
+				fmt.Printf("Skipping key %s.%s: only arrays of objects are generated, as has-many models\n", spec.Name, key)
+				continue
+			}
+			related := tableStructName(key)
+			if err := in.infer(related, value); err != nil {
+				return err
+			}
+			addInferredField(in.specs[related], ModelField{Name: spec.Name + "Id", Type: in.key, Relation: spec.Name}, false)
+			if !hasManyField(spec, fieldName) {
+				relation := ModelField{Name: fieldName, Relation: related}
+				if relation.JSONName() != key {
+					relation.JSON = key
+				}
+				spec.HasMany = append(spec.HasMany, relation)
+			}
+		default:
+			field := inferScalarField(fieldName, value)
+			if field.JSONName() != key {
+				field.JSON = key
+			}
+			seen[field.Name] = true
+			addInferredField(spec, field, first)
+		}
+	}
+
+	for i := range spec.Fields {
+		if !seen[spec.Fields[i].Name] && spec.Fields[i].Relation == "" {
+			spec.Fields[i].Nullable = true
+		}
+	}
+	return nil
+}
+
+// inferScalarField returns the field of a JSON scalar. Null values leave the type to be inferred from other objects.
+func inferScalarField(name string, value interface{}) ModelField {
+	field := ModelField{Name: name}
+	switch value := value.(type) {
+	case nil:
+		field.Nullable = true
+	case bool:
+		field.Type = "bool"
+		field.Example = fmt.Sprint(value)
+	case json.Number:
+		field.Type = "int"
+		if strings.ContainsAny(value.String(), ".eE") {
+			field.Type = "float64"
+		}
+		field.Example = value.String()
+	case string:
+		field.Type = "string"
+		if _, err := time.Parse(time.RFC3339, value); err == nil {
+			field.Type = "time.Time"
+		}
+		field.Example = value
+	}
+	return field
+}
+
+// addInferredField adds a field to a spec, merging it with the field of the same name inferred from another object.
+// Fields first seen after the first object are nullable.
+func addInferredField(spec *ModelSpec, field ModelField, first bool) {
+	for i := range spec.Fields {
+		existing := &spec.Fields[i]
+		if existing.Name != field.Name {
+			continue
+		}
+
+		switch {
+		case field.Relation != "":
+			existing.Type, existing.Relation, existing.Example = field.Type, field.Relation, ""
+		case existing.Relation != "", field.Type == "", existing.Type == field.Type:
+		case existing.Type == "":
+			existing.Type = field.Type
+		case isNumericType(existing.Type) && isNumericType(field.Type):
+			existing.Type = "float64"
+		default:
+			existing.Type, existing.Example = "string", ""
+		}
+		existing.Nullable = existing.Nullable || field.Nullable
+		if existing.Example == "" && existing.Type == field.Type {
+			existing.Example = field.Example
+		}
+		return
+	}
+
+	if !first && field.Relation == "" {
+		field.Nullable = true
+	}
+	spec.Fields = append(spec.Fields, field)
+}
+
+// hasManyField reports whether the spec already has the has-many relationship name.
+func hasManyField(spec *ModelSpec, name string) bool {
+	for _, relation := range spec.HasMany {
+		if relation.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isNumericType reports whether goType is one of the number types inferred from JSON.
+func isNumericType(goType string) bool {
+	return goType == "int" || goType == "float64"
+}
+
+// promptInferredTypes shows the fields inferred for a model and lets the user change their types.
+func promptInferredTypes(spec *ModelSpec) {
+	fmt.Printf("Inferred fields of %s:\n", spec.Name)
+	for _, field := range spec.Fields {
+		nullable := ""
+		if field.Nullable {
+			nullable = " (nullable)"
+		}
+		fmt.Printf("- %s %s%s\n", field.Name, field.Type, nullable)
+	}
+	for _, relation := range spec.HasMany {
+		fmt.Printf("- %s []%s\n", relation.Name, relation.Relation)
+	}
+
+	fmt.Print("Adjust the inferred types? (y/n): ")
+	choice := readWord()
+	if strings.ToLower(choice) != "y" {
+		return
+	}
+
+	for i := range spec.Fields {
+		field := &spec.Fields[i]
+		if field.Relation != "" {
+			continue
+		}
+
+		fmt.Printf("Keep %s as %s? (y/n): ", field.Name, field.Type)
+		choice = readWord()
+		if strings.ToLower(choice) == "n" {
+			field.Type = selectType().GoType
+			field.Example = ""
+		}
+	}
+}
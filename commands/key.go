@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// KeyType describes a primary key type models can be declared with, and how each layer handles it.
+type KeyType struct {
+	Name      string // name of the key type in the flags and the project configuration
+	GoType    string // type of the ID field and of the id parameters
+	Import    string // package declaring GoType or generating the keys
+	Tag       string // gorm settings of the ID field besides its column
+	New       string // expression generating the key of new records, empty when the database assigns it
+	Zero      string // zero value of GoType
+	Parse     string // statements parsing the id path parameter named %[1]s into id and err
+	Arg       string // expression passing the parsed id to the service
+	Swagger   string // Swagger type of the id path parameter
+	TestID    string // valid id in the paths of the handler tests
+	MissingID string // expression of an id no record has, in the repository tests
+}
+
+// keyTypes lists the supported primary key types by name.
+var keyTypes = map[string]KeyType{
+	"uint": {
+		Name:      "uint",
+		GoType:    "uint",
+		Tag:       "primaryKey;autoIncrement",
+		Zero:      "0",
+		Parse:     `id, err := strconv.Atoi(c.Params("%[1]s"))`,
+		Arg:       "uint(id)",
+		Swagger:   "int",
+		TestID:    "1",
+		MissingID: "999",
+	},
+	"int64": {
+		Name:      "int64",
+		GoType:    "int64",
+		Tag:       "primaryKey;autoIncrement",
+		Zero:      "0",
+		Parse:     `id, err := strconv.ParseInt(c.Params("%[1]s"), 10, 64)`,
+		Arg:       "id",
+		Swagger:   "int",
+		TestID:    "1",
+		MissingID: "999",
+	},
+	"uuid": {
+		Name:      "uuid",
+		GoType:    "uuid.UUID",
+		Import:    "github.com/google/uuid",
+		Tag:       "primaryKey",
+		New:       "uuid.New()",
+		Zero:      "uuid.Nil",
+		Parse:     `id, err := uuid.Parse(c.Params("%[1]s"))`,
+		Arg:       "id",
+		Swagger:   "string",
+		TestID:    "123e4567-e89b-12d3-a456-426614174000",
+		MissingID: "uuid.New()",
+	},
+	// ULIDs are kept as strings, so they are stored and sorted as text
+	"ulid": {
+		Name:   "ulid",
+		GoType: "string",
+		Import: "github.com/oklog/ulid/v2",
+		Tag:    "type:char(26);primaryKey",
+		New:    "ulid.Make().String()",
+		Zero:   `""`,
+		Parse: `id := c.Params("%[1]s")
+	_, err := ulid.ParseStrict(id)`,
+		Arg:       "id",
+		Swagger:   "string",
+		TestID:    "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		MissingID: "ulid.Make().String()",
+	},
+}
+
+// GetKeyType returns the key type registered under name.
+func GetKeyType(name string) (KeyType, error) {
+	key, ok := keyTypes[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(keyTypes))
+		for name := range keyTypes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return KeyType{}, fmt.Errorf("unsupported key type %q, supported key types are: %s", name, strings.Join(names, ", "))
+	}
+	return key, nil
+}
+
+// structKeyType returns the key type of an ID field declared with goType, ULIDs being strings.
+func structKeyType(goType string) (KeyType, bool) {
+	for _, name := range []string{"uint", "int64", "uuid", "ulid"} {
+		if keyTypes[name].GoType == goType {
+			return keyTypes[name], true
+		}
+	}
+	return KeyType{}, false
+}
+
+// key returns the primary key type of the model, the one of the project when the options leave it empty.
+func (o ModelOptions) key() KeyType {
+	name := o.Key
+	if name == "" {
+		if config, err := LoadProjectConfig(); err == nil {
+			name = config.KeyType
+		}
+	}
+	if key, err := GetKeyType(name); err == nil {
+		return key
+	}
+	return keyTypes["uint"]
+}
+
+// lookup returns the arguments of First finding a record by id. Integers are matched against the primary key,
+// other keys through a condition on the ID column, as GORM reads a string argument as SQL.
+func (k KeyType) lookup(column string) string {
+	if isIntegerType(k.GoType) {
+		return "id"
+	}
+	return fmt.Sprintf(`"%s = ?", id`, column)
+}
+
+// ParseID returns the statements parsing the path parameter named param into id and err.
+func (k KeyType) ParseID(param string) string {
+	return fmt.Sprintf(k.Parse, param)
+}
+
+// IDTag returns the gorm settings of the ID field: the SQL type of generated keys in the project dialect,
+// then the primary key settings.
+func (k KeyType) IDTag() string {
+	if fieldType, ok := LookupFieldType(k.GoType); ok && k.GoType != "string" {
+		if sqlType := fieldType.SQLTypes[projectDialect()]; sqlType != "" {
+			return "type:" + sqlType + ";" + k.Tag
+		}
+	}
+	return k.Tag
+}
+
+// ImportLine returns the import of the package of the key type when code uses it, as a tab-indented line, or an empty string.
+func (k KeyType) ImportLine(code string) string {
+	if k.Import == "" || !strings.Contains(code, k.packageName()+".") {
+		return ""
+	}
+	return fmt.Sprintf("\t%q\n", k.Import)
+}
+
+// packageName returns the name the package of the key type is used with, like "ulid".
+func (k KeyType) packageName() string {
+	return FieldType{Import: k.Import}.PackageName()
+}
+
+// beforeCreateHook returns the GORM hook setting the key of new records, or an empty string
+// when the database assigns it.
+func (k KeyType) beforeCreateHook(structName string) string {
+	if k.New == "" {
+		return ""
+	}
+	receiver := strings.ToLower(structName[:1])
+	return fmt.Sprintf(`
+// BeforeCreate sets the ID of new records.
+func (%[1]s *%[2]s) BeforeCreate(tx *gorm.DB) error {
+	if %[1]s.ID == %[3]s {
+		%[1]s.ID = %[4]s
+	}
+	return nil
+}
+`, receiver, structName, k.Zero, k.New)
+}
+
+// relationKeyType returns the Go type of the foreign keys pointing at a model: the type of its ID,
+// or the one of fallback when the model is not declared yet.
+func relationKeyType(structName string, fallback KeyType) string {
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fallback.GoType
+	}
+	model, err := FindModel(models, structName)
+	if err != nil {
+		return fallback.GoType
+	}
+	for _, field := range model.Fields {
+		for _, name := range field.Names {
+			if name.Name == "ID" {
+				return types.ExprString(field.Type)
+			}
+		}
+	}
+	return fallback.GoType
+}
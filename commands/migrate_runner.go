@@ -0,0 +1,247 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeMigrateRunner writes the program that applies the SQL migrations of the project.
+// It is regenerated on every migration so it always matches the configured dialect.
+func writeMigrateRunner(dialect string) error {
+	driver, ok := dialectDrivers[dialect]
+	if !ok {
+		return fmt.Errorf("unsupported dialect %q, supported dialects are: postgres, mysql, sqlite", dialect)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(migrateRunnerPath), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating migrate directory: %v", err)
+	}
+
+	file, err := os.Create(migrateRunnerPath)
+	if err != nil {
+		return fmt.Errorf("error creating migrate runner: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	// Write the content
+	content := fmt.Sprintf(`// Command migrate applies the SQL migrations generated by silveirinha.
+//
+// Usage:
+//
+//	go run ./cmd/migrate [-dir migrations] [-dsn DSN] [-steps N] up|down|status
+//
+// The connection string defaults to the DATABASE_DSN environment variable.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"%[1]s"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// migration is a pair of up/down SQL files sharing a version.
+type migration struct {
+	Version  string
+	Name     string
+	UpPath   string
+	DownPath string
+}
+
+// schemaMigration records an applied migration.
+type schemaMigration struct {
+	Version   string    `+"`"+`gorm:"primaryKey;size:14"`+"`"+`
+	AppliedAt time.Time `+"`"+`gorm:"not null"`+"`"+`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+func main() {
+	dir := flag.String("dir", "migrations", "directory holding the migration files")
+	dsn := flag.String("dsn", os.Getenv("DATABASE_DSN"), "database connection string")
+	steps := flag.Int("steps", 1, "number of migrations reverted by down")
+	flag.Parse()
+
+	if *dsn == "" {
+		log.Fatal("no connection string, set -dsn or DATABASE_DSN")
+	}
+
+	db, err := gorm.Open(%[2]s.Open(*dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		log.Fatalf("error connecting to the database: %%v", err)
+	}
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		log.Fatalf("error creating schema_migrations: %%v", err)
+	}
+
+	migrations, err := loadMigrations(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	applied, err := appliedVersions(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch flag.Arg(0) {
+	case "up":
+		err = up(db, migrations, applied)
+	case "down":
+		err = down(db, migrations, applied, *steps)
+	case "status":
+		status(migrations, applied)
+	default:
+		log.Fatalf("unknown command %%q, use up, down or status", flag.Arg(0))
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadMigrations lists the migrations in dir ordered by version.
+func loadMigrations(dir string) ([]migration, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing migrations: %%v", err)
+	}
+
+	var migrations []migration
+	for _, path := range paths {
+		base := strings.TrimSuffix(filepath.Base(path), ".up.sql")
+		version, name, _ := strings.Cut(base, "_")
+		migrations = append(migrations, migration{
+			Version:  version,
+			Name:     name,
+			UpPath:   path,
+			DownPath: filepath.Join(dir, base+".down.sql"),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// appliedVersions returns the versions recorded in schema_migrations.
+func appliedVersions(db *gorm.DB) (map[string]time.Time, error) {
+	var records []schemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("error reading schema_migrations: %%v", err)
+	}
+
+	applied := map[string]time.Time{}
+	for _, record := range records {
+		applied[record.Version] = record.AppliedAt
+	}
+	return applied, nil
+}
+
+// up applies every pending migration, each one in its own transaction.
+func up(db *gorm.DB, migrations []migration, applied map[string]time.Time) error {
+	pending := 0
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		pending++
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := execFile(tx, m.UpPath); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: m.Version, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("error applying %%s_%%s: %%v", m.Version, m.Name, err)
+		}
+		fmt.Printf("Applied %%s_%%s\n", m.Version, m.Name)
+	}
+
+	if pending == 0 {
+		fmt.Println("No pending migrations.")
+	}
+	return nil
+}
+
+// down reverts the last steps applied migrations, newest first.
+func down(db *gorm.DB, migrations []migration, applied map[string]time.Time, steps int) error {
+	for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+		m := migrations[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		steps--
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := execFile(tx, m.DownPath); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{Version: m.Version}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("error reverting %%s_%%s: %%v", m.Version, m.Name, err)
+		}
+		fmt.Printf("Reverted %%s_%%s\n", m.Version, m.Name)
+	}
+	return nil
+}
+
+// status prints every migration with the time it was applied.
+func status(migrations []migration, applied map[string]time.Time) {
+	for _, m := range migrations {
+		state := "pending"
+		if appliedAt, ok := applied[m.Version]; ok {
+			state = "applied " + appliedAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%%s_%%s\t%%s\n", m.Version, m.Name, state)
+	}
+}
+
+// execFile runs the statements of a migration file one by one.
+// Comment lines are skipped and statements are separated by a semicolon at the end of a line.
+func execFile(tx *gorm.DB, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %%s: %%v", path, err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			lines = append(lines, line)
+		}
+	}
+
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";\n") {
+		statement = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+		if statement == "" {
+			continue
+		}
+		if err := tx.Exec(statement).Error; err != nil {
+			return fmt.Errorf("%%v\n%%s", err, statement)
+		}
+	}
+	return nil
+}
+`, driver, filepath.Base(driver))
+
+	writer.WriteString(content)
+	writer.Flush()
+
+	fmt.Printf("Migration runner generated at %s, run `go get %s` if it is not yet a dependency.\n", migrateRunnerPath, driver)
+	return nil
+}
@@ -0,0 +1,607 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Paths used by the migration commands, relative to the project root.
+var (
+	migrationsDir      = "migrations"
+	schemaSnapshotPath = filepath.Join(".silveirinha", "schema.json")
+	migrateRunnerPath  = filepath.Join("cmd", "migrate", "main.go")
+)
+
+// GenerateMigration diffs the model structs against the last recorded schema snapshot
+// and writes a timestamped pair of up/down SQL files for the project dialect.
+// When nothing changed no file is written.
+func GenerateMigration(name string) error {
+	return generateMigration(name, schemaRenames{})
+}
+
+// schemaRenames holds the tables and columns known to be renamed, which are migrated without asking.
+type schemaRenames struct {
+	Tables  map[string]string            // new table name by old table name
+	Columns map[string]map[string]string // new column name by table and old column name
+}
+
+// generateMigration is GenerateMigration with the tables and columns known to be renamed.
+// The user is only asked about the other candidate renames.
+func generateMigration(name string, renames schemaRenames) error {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	dialect, err := GetDialect(config.Dialect)
+	if err != nil {
+		return err
+	}
+
+	current, err := ReadModelSchema(filepath.Join("internal", "app", "domain", "model"))
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+
+	previous, err := loadSchemaSnapshot()
+	if err != nil {
+		return err
+	}
+
+	up, down, err := diffSchemas(previous, current, dialect, renames)
+	if err != nil {
+		return fmt.Errorf("error comparing schemas: %v", err)
+	}
+	if len(up) == 0 {
+		fmt.Println("No schema changes detected.")
+		return nil
+	}
+
+	if err := os.MkdirAll(migrationsDir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating migrations directory: %v", err)
+	}
+
+	// Versions must stay unique, even for migrations generated within the same second
+	now := time.Now().UTC()
+	version := now.Format("20060102150405")
+	for {
+		existing, _ := filepath.Glob(filepath.Join(migrationsDir, version+"_*"))
+		if len(existing) == 0 {
+			break
+		}
+		now = now.Add(time.Second)
+		version = now.Format("20060102150405")
+	}
+	baseName := fmt.Sprintf("%s_%s", version, migrationFileName(name))
+	upFilePath := filepath.Join(migrationsDir, baseName+".up.sql")
+	downFilePath := filepath.Join(migrationsDir, baseName+".down.sql")
+
+	if err := os.WriteFile(upFilePath, []byte(strings.Join(up, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", upFilePath, err)
+	}
+	if err := os.WriteFile(downFilePath, []byte(strings.Join(down, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", downFilePath, err)
+	}
+
+	if err := saveSchemaSnapshot(current); err != nil {
+		return err
+	}
+
+	if err := writeMigrateRunner(dialect.Name()); err != nil {
+		return err
+	}
+
+	fmt.Printf("Migration files generated:\n- %s\n- %s\n", upFilePath, downFilePath)
+	return nil
+}
+
+// RunMigrations applies ("up"), reverts ("down") or lists ("status") the project migrations.
+// The work is done by the runner generated in the project, so the database driver stays a
+// dependency of the project and not of silveirinha.
+func RunMigrations(action, dsn string, steps int) error {
+	config, err := LoadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(migrateRunnerPath); os.IsNotExist(err) {
+		if err := writeMigrateRunner(config.Dialect); err != nil {
+			return err
+		}
+	}
+
+	args := []string{"run", "./" + filepath.ToSlash(filepath.Dir(migrateRunnerPath)), "-dir", migrationsDir}
+	if dsn != "" {
+		args = append(args, "-dsn", dsn)
+	}
+	if action == "down" {
+		args = append(args, "-steps", fmt.Sprint(steps))
+	}
+	args = append(args, action)
+
+	cmd := exec.Command("go", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error running migrations %s: %v", action, err)
+	}
+	return nil
+}
+
+// migrationFileName turns a free-form migration name into a file name fragment.
+func migrationFileName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "schema_update"
+	}
+	return strings.Trim(strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return '_'
+	}, name), "_")
+}
+
+// loadSchemaSnapshot reads the schema recorded by the last generated migration.
+// A project without a snapshot starts from an empty schema.
+func loadSchemaSnapshot() (*Schema, error) {
+	content, err := os.ReadFile(schemaSnapshotPath)
+	if os.IsNotExist(err) {
+		return &Schema{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema snapshot: %v", err)
+	}
+
+	schema := &Schema{}
+	if err := json.Unmarshal(content, schema); err != nil {
+		return nil, fmt.Errorf("error parsing schema snapshot: %v", err)
+	}
+	return schema, nil
+}
+
+// saveSchemaSnapshot records schema as the state of the database after the latest migration.
+func saveSchemaSnapshot(schema *Schema) error {
+	if err := os.MkdirAll(filepath.Dir(schemaSnapshotPath), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating snapshot directory: %v", err)
+	}
+
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding schema snapshot: %v", err)
+	}
+
+	if err := os.WriteFile(schemaSnapshotPath, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing schema snapshot: %v", err)
+	}
+	return nil
+}
+
+// diffSchemas returns the statements that migrate the database from previous to current,
+// and the statements that revert them.
+func diffSchemas(previous, current *Schema, dialect Dialect, renames schemaRenames) ([]string, []string, error) {
+	var up, down []string
+
+	for _, table := range current.Tables {
+		old := previous.Table(table.Name)
+		if old == nil {
+			old = renamedTable(previous, current, table.Name, renames.Tables)
+			if old != nil {
+				renameUp, renameDown := renameTableStatements(dialect, *old, table.Name)
+				up = append(up, renameUp...)
+				down = prependStatements(down, renameDown...)
+				renamed := *old
+				renamed.Name = table.Name
+				old = &renamed
+			}
+		}
+		if old == nil {
+			create, err := createTableStatements(dialect, table)
+			if err != nil {
+				return nil, nil, err
+			}
+			up = append(up, create...)
+			down = prependStatements(down, fmt.Sprintf("DROP TABLE %s;", dialect.Quote(table.Name)))
+			continue
+		}
+
+		tableUp, tableDown, err := diffTable(*old, table, dialect, renames.Columns[table.Name])
+		if err != nil {
+			return nil, nil, err
+		}
+		up = append(up, tableUp...)
+		down = prependStatements(down, tableDown...)
+	}
+
+	for _, table := range previous.Tables {
+		if current.Table(table.Name) != nil || current.Table(renames.Tables[table.Name]) != nil {
+			continue
+		}
+		create, err := createTableStatements(dialect, table)
+		if err != nil {
+			return nil, nil, err
+		}
+		up = append(up, fmt.Sprintf("DROP TABLE %s;", dialect.Quote(table.Name)))
+		down = prependStatements(down, create...)
+	}
+
+	return up, down, nil
+}
+
+// renamedTable returns the previous version of a table known to be renamed to name.
+func renamedTable(previous, current *Schema, name string, renames map[string]string) *Table {
+	for oldName, newName := range renames {
+		if newName == name && current.Table(oldName) == nil {
+			return previous.Table(oldName)
+		}
+	}
+	return nil
+}
+
+// renameTableStatements returns the statements that rename a table and revert it. Index and check
+// names contain the table name, so they are dropped before the rename and created again after it.
+// SQLite keeps the check names, as it cannot change them.
+func renameTableStatements(dialect Dialect, old Table, name string) ([]string, []string) {
+	_, sqlite := dialect.(sqliteDialect)
+	var up, down []string
+	for _, column := range old.Columns {
+		up = append(up, dropIndexStatements(dialect, old.Name, column)...)
+		down = append(down, dropIndexStatements(dialect, name, column)...)
+		if !sqlite {
+			up = append(up, dropCheckStatements(dialect, old.Name, column)...)
+			down = append(down, dropCheckStatements(dialect, name, column)...)
+		}
+	}
+	up = append(up, fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", dialect.Quote(old.Name), dialect.Quote(name)))
+	down = append(down, fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", dialect.Quote(name), dialect.Quote(old.Name)))
+	for _, column := range old.Columns {
+		up = append(up, createIndexStatements(dialect, name, column)...)
+		down = append(down, createIndexStatements(dialect, old.Name, column)...)
+		if !sqlite {
+			up = append(up, createCheckStatements(dialect, name, column)...)
+			down = append(down, createCheckStatements(dialect, old.Name, column)...)
+		}
+	}
+	return up, down
+}
+
+// diffTable compares two versions of a table. Columns that disappeared while a column of the
+// same Go type appeared are offered as renames, since a diff can't tell both cases apart.
+// knownRenames maps old column names to new ones that are renames without asking.
+func diffTable(old, new Table, dialect Dialect, knownRenames map[string]string) ([]string, []string, error) {
+	var up, down []string
+	quotedTable := dialect.Quote(new.Name)
+
+	// Named indexes that disappeared or changed are dropped before the columns change, and created again after
+	for _, index := range old.Indexes {
+		if current := new.Index(index.Name); current == nil || !sameIndex(index, *current) {
+			up = append(up, dropIndexStatement(dialect, new.Name, index.Name))
+			down = prependStatements(down, createNamedIndexStatement(dialect, new.Name, index))
+		}
+	}
+
+	var added, removed []Column
+	for _, column := range new.Columns {
+		if old.Column(column.Name) == nil {
+			added = append(added, column)
+		}
+	}
+	for _, column := range old.Columns {
+		if new.Column(column.Name) == nil {
+			removed = append(removed, column)
+		}
+	}
+
+	// Ask whether removed columns were renamed to one of the added ones, unless it is already known
+	renamed := map[string]string{}
+	for oldName, newName := range knownRenames {
+		if old.Column(oldName) != nil && new.Column(oldName) == nil && new.Column(newName) != nil && old.Column(newName) == nil {
+			renamed[oldName] = newName
+		}
+	}
+	for _, oldColumn := range removed {
+		if _, known := renamed[oldColumn.Name]; known {
+			continue
+		}
+		for _, newColumn := range added {
+			if oldColumn.GoType != newColumn.GoType || isRenameTarget(renamed, newColumn.Name) {
+				continue
+			}
+			fmt.Printf("Was column %s.%s renamed to %s? (y/n): ", new.Name, oldColumn.Name, newColumn.Name)
+			choice := readWord()
+			if strings.ToLower(choice) == "y" {
+				renamed[oldColumn.Name] = newColumn.Name
+				break
+			}
+		}
+	}
+
+	for _, oldColumn := range removed {
+		if newName, ok := renamed[oldColumn.Name]; ok {
+			// Check names contain the column name, so the check is created again after the rename
+			up = append(up, dropCheckStatements(dialect, new.Name, oldColumn)...)
+			down = prependStatements(down, createCheckStatements(dialect, new.Name, oldColumn)...)
+			up = append(up, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", quotedTable, dialect.Quote(oldColumn.Name), dialect.Quote(newName)))
+			down = prependStatements(down, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s;", quotedTable, dialect.Quote(newName), dialect.Quote(oldColumn.Name)))
+
+			// The renamed column may also have changed its definition
+			renamedColumn := *new.Column(newName)
+			previousColumn := oldColumn
+			previousColumn.Name = newName
+			previousColumn.Check = ""
+			alterUp, alterDown, err := diffColumn(new.Name, previousColumn, renamedColumn, dialect)
+			if err != nil {
+				return nil, nil, err
+			}
+			up = append(up, alterUp...)
+			down = prependStatements(down, alterDown...)
+			continue
+		}
+
+		definition, err := columnDefinition(dialect, oldColumn)
+		if err != nil {
+			return nil, nil, err
+		}
+		up = append(up, dropIndexStatements(dialect, new.Name, oldColumn)...)
+		up = append(up, dropCheckStatements(dialect, new.Name, oldColumn)...)
+		up = append(up, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", quotedTable, dialect.Quote(oldColumn.Name)))
+		restore := []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", quotedTable, definition)}
+		if oldColumn.Comment != "" {
+			restore = append(restore, commentStatements(dialect, new.Name, oldColumn)...)
+		}
+		restore = append(restore, createCheckStatements(dialect, new.Name, oldColumn)...)
+		down = prependStatements(down, append(restore, createIndexStatements(dialect, new.Name, oldColumn)...)...)
+	}
+
+	for _, newColumn := range added {
+		if isRenameTarget(renamed, newColumn.Name) {
+			continue
+		}
+		definition, err := columnDefinition(dialect, newColumn)
+		if err != nil {
+			return nil, nil, err
+		}
+		up = append(up, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", quotedTable, definition))
+		if newColumn.Comment != "" {
+			up = append(up, commentStatements(dialect, new.Name, newColumn)...)
+		}
+		up = append(up, createCheckStatements(dialect, new.Name, newColumn)...)
+		up = append(up, createIndexStatements(dialect, new.Name, newColumn)...)
+		drop := append(dropIndexStatements(dialect, new.Name, newColumn), dropCheckStatements(dialect, new.Name, newColumn)...)
+		down = prependStatements(down, append(drop,
+			fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", quotedTable, dialect.Quote(newColumn.Name)))...)
+	}
+
+	for _, newColumn := range new.Columns {
+		oldColumn := old.Column(newColumn.Name)
+		if oldColumn == nil {
+			continue
+		}
+		alterUp, alterDown, err := diffColumn(new.Name, *oldColumn, newColumn, dialect)
+		if err != nil {
+			return nil, nil, err
+		}
+		up = append(up, alterUp...)
+		down = prependStatements(down, alterDown...)
+	}
+
+	for _, index := range new.Indexes {
+		if previous := old.Index(index.Name); previous == nil || !sameIndex(*previous, index) {
+			up = append(up, createNamedIndexStatement(dialect, new.Name, index))
+			down = prependStatements(down, dropIndexStatement(dialect, new.Name, index.Name))
+		}
+	}
+
+	return up, down, nil
+}
+
+// diffColumn returns the statements that change a column definition and revert it.
+func diffColumn(table string, old, new Column, dialect Dialect) ([]string, []string, error) {
+	var up, down []string
+
+	oldType, err := dialect.ColumnType(old)
+	if err != nil {
+		return nil, nil, err
+	}
+	newType, err := dialect.ColumnType(new)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// MySQL changes comments with the column definition
+	_, mysql := dialect.(mysqlDialect)
+	if oldType != newType || old.NotNull != new.NotNull || old.Default != new.Default || (mysql && old.Comment != new.Comment) {
+		alterUp, err := dialect.AlterColumn(table, old, new)
+		if err != nil {
+			return nil, nil, err
+		}
+		alterDown, err := dialect.AlterColumn(table, new, old)
+		if err != nil {
+			return nil, nil, err
+		}
+		up = append(up, alterUp...)
+		down = prependStatements(down, alterDown...)
+	}
+
+	if old.Index != new.Index || old.Unique != new.Unique {
+		up = append(up, dropIndexStatements(dialect, table, old)...)
+		up = append(up, createIndexStatements(dialect, table, new)...)
+		down = prependStatements(down, append(dropIndexStatements(dialect, table, new), createIndexStatements(dialect, table, old)...)...)
+	}
+
+	if old.Comment != new.Comment {
+		up = append(up, commentStatements(dialect, table, new)...)
+		down = prependStatements(down, commentStatements(dialect, table, old)...)
+	}
+
+	if old.Check != new.Check {
+		up = append(up, dropCheckStatements(dialect, table, old)...)
+		up = append(up, createCheckStatements(dialect, table, new)...)
+		down = prependStatements(down, append(dropCheckStatements(dialect, table, new), createCheckStatements(dialect, table, old)...)...)
+	}
+
+	return up, down, nil
+}
+
+// createTableStatements returns the CREATE TABLE statement of a table followed by its indexes.
+func createTableStatements(dialect Dialect, table Table) ([]string, error) {
+	var definitions []string
+	var indexes []string
+	for _, column := range table.Columns {
+		definition, err := columnDefinition(dialect, column)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %v", table.Name, err)
+		}
+		definitions = append(definitions, "\t"+definition)
+		indexes = append(indexes, createIndexStatements(dialect, table.Name, column)...)
+	}
+	for _, index := range table.Indexes {
+		indexes = append(indexes, createNamedIndexStatement(dialect, table.Name, index))
+	}
+	for _, column := range table.Columns {
+		if column.Check != "" {
+			definitions = append(definitions, fmt.Sprintf("\tCONSTRAINT %s CHECK (%s)", dialect.Quote(checkName(table.Name, column)), column.Check))
+		}
+	}
+
+	create := fmt.Sprintf("CREATE TABLE %s (\n%s\n);", dialect.Quote(table.Name), strings.Join(definitions, ",\n"))
+	statements := append([]string{create}, indexes...)
+	for _, column := range table.Columns {
+		if column.Comment != "" {
+			statements = append(statements, commentStatements(dialect, table.Name, column)...)
+		}
+	}
+	return statements, nil
+}
+
+// createIndexStatements returns the index statements of a column, named as GORM names them.
+func createIndexStatements(dialect Dialect, table string, column Column) []string {
+	if column.PrimaryKey {
+		return nil
+	}
+	if column.Unique {
+		return []string{fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s);", dialect.Quote(indexName("uni", table, column)), dialect.Quote(table), dialect.Quote(column.Name))}
+	}
+	if column.Index {
+		return []string{fmt.Sprintf("CREATE INDEX %s ON %s (%s);", dialect.Quote(indexName("idx", table, column)), dialect.Quote(table), dialect.Quote(column.Name))}
+	}
+	return nil
+}
+
+// dropIndexStatements returns the statements that drop the indexes of a column.
+func dropIndexStatements(dialect Dialect, table string, column Column) []string {
+	if column.PrimaryKey {
+		return nil
+	}
+
+	var name string
+	switch {
+	case column.Unique:
+		name = indexName("uni", table, column)
+	case column.Index:
+		name = indexName("idx", table, column)
+	default:
+		return nil
+	}
+	return []string{dropIndexStatement(dialect, table, name)}
+}
+
+// createNamedIndexStatement returns the statement creating a named index, on the columns in its order.
+func createNamedIndexStatement(dialect Dialect, table string, index Index) string {
+	columns := make([]string, len(index.Columns))
+	for i, column := range index.Columns {
+		columns[i] = dialect.Quote(column)
+	}
+	unique := ""
+	if index.Unique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s);", unique, dialect.Quote(index.Name), dialect.Quote(table), strings.Join(columns, ", "))
+}
+
+// dropIndexStatement returns the statement dropping an index of a table.
+func dropIndexStatement(dialect Dialect, table, name string) string {
+	if _, ok := dialect.(mysqlDialect); ok {
+		return fmt.Sprintf("DROP INDEX %s ON %s;", dialect.Quote(name), dialect.Quote(table))
+	}
+	return fmt.Sprintf("DROP INDEX %s;", dialect.Quote(name))
+}
+
+// sameIndex reports whether two versions of a named index have the same columns and uniqueness.
+func sameIndex(a, b Index) bool {
+	return a.Unique == b.Unique && slices.Equal(a.Columns, b.Columns)
+}
+
+// createCheckStatements returns the statement adding the check constraint of a column, named as GORM names it.
+func createCheckStatements(dialect Dialect, table string, column Column) []string {
+	if column.Check == "" {
+		return nil
+	}
+	if _, ok := dialect.(sqliteDialect); ok {
+		return []string{fmt.Sprintf("-- SQLite cannot add the check constraint of column %s of table %s, rebuild the table to apply this change.", dialect.Quote(column.Name), dialect.Quote(table))}
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);", dialect.Quote(table), dialect.Quote(checkName(table, column)), column.Check)}
+}
+
+// dropCheckStatements returns the statement dropping the check constraint of a column.
+func dropCheckStatements(dialect Dialect, table string, column Column) []string {
+	if column.Check == "" {
+		return nil
+	}
+	switch dialect.(type) {
+	case sqliteDialect:
+		return []string{fmt.Sprintf("-- SQLite cannot drop the check constraint of column %s of table %s, rebuild the table to apply this change.", dialect.Quote(column.Name), dialect.Quote(table))}
+	case mysqlDialect:
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP CHECK %s;", dialect.Quote(table), dialect.Quote(checkName(table, column)))}
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", dialect.Quote(table), dialect.Quote(checkName(table, column)))}
+}
+
+// commentStatements returns the statement setting the comment of a column, or removing it when the column has none.
+// Only PostgreSQL needs one: MySQL declares comments with the column and SQLite does not store them.
+func commentStatements(dialect Dialect, table string, column Column) []string {
+	if _, ok := dialect.(postgresDialect); !ok {
+		return nil
+	}
+	comment := "NULL"
+	if column.Comment != "" {
+		comment = sqlQuote(column.Comment)
+	}
+	return []string{fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", dialect.Quote(table), dialect.Quote(column.Name), comment)}
+}
+
+// checkName builds a check constraint name following GORM's chk_<table>_<column> convention.
+func checkName(table string, column Column) string {
+	return fmt.Sprintf("chk_%s_%s", table, column.Name)
+}
+
+// indexName builds an index name following GORM's idx_<table>_<column> convention.
+func indexName(prefix, table string, column Column) string {
+	return fmt.Sprintf("%s_%s_%s", prefix, table, column.Name)
+}
+
+// isRenameTarget reports whether name was already chosen as the new name of a renamed column.
+func isRenameTarget(renamed map[string]string, name string) bool {
+	for _, target := range renamed {
+		if target == name {
+			return true
+		}
+	}
+	return false
+}
+
+// prependStatements puts the statements of a change before the ones collected so far.
+// Down migrations revert the changes in the opposite order they were applied.
+func prependStatements(statements []string, change ...string) []string {
+	return append(append([]string{}, change...), statements...)
+}
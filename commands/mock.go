@@ -0,0 +1,239 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// GenerateMocks generates hand-rolled mocks for the repository and service interfaces of a model.
+// The mocks have no third-party dependencies: every method delegates to an optional function
+// field and records its arguments so tests can assert on the calls made.
+func GenerateMocks(modelName, structName string, options ModelOptions) error {
+	// Get the current working directory
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current working directory: %v", err)
+	}
+
+	// Get the current folder name
+	currentFolderName := filepath.Base(currentDir)
+
+	// Construct the mocks directory path
+	mocksDir := filepath.Join("internal", "app", "domain", "mocks")
+
+	// Ensure the mocks directory exists
+	if err := os.MkdirAll(mocksDir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating mocks directory: %v", err)
+	}
+
+	// Generate the shared call recorder only once per project
+	callFilePath := filepath.Join(mocksDir, "call.go")
+	if _, err := os.Stat(callFilePath); os.IsNotExist(err) {
+		if err := writeMockCallFile(callFilePath); err != nil {
+			return fmt.Errorf("error writing mock call file: %v", err)
+		}
+	}
+
+	// Generate the Repository mock file
+	repositoryImport := fmt.Sprintf("%sRepository \"%s/internal/app/domain/repository/%s\"", modelName, currentFolderName, modelName)
+	repositoryMockFilePath := filepath.Join(mocksDir, fmt.Sprintf("%sRepositoryMock.go", modelName))
+	if err := writeMockFile(repositoryMockFilePath, currentFolderName, repositoryImport, modelName, structName, "Repository", options); err != nil {
+		return fmt.Errorf("error writing repository mock file: %v", err)
+	}
+
+	// Generate the Service mock file
+	serviceImport := fmt.Sprintf("%sService \"%s/internal/app/domain/service/%s\"", modelName, currentFolderName, modelName)
+	serviceMockFilePath := filepath.Join(mocksDir, fmt.Sprintf("%sServiceMock.go", modelName))
+	if err := writeMockFile(serviceMockFilePath, currentFolderName, serviceImport, modelName, structName, "Service", options); err != nil {
+		return fmt.Errorf("error writing service mock file: %v", err)
+	}
+
+	fmt.Printf("Mock files generated in: %s\n", mocksDir)
+	return nil
+}
+
+// writeMockCallFile creates the call recorder shared by every generated mock.
+func writeMockCallFile(filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	// Write the content
+	content := `package mocks
+
+import "sync"
+
+// Call is a single recorded invocation of a mocked method.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// recorder keeps the calls made to a mock in order. It is safe for concurrent use.
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns every recorded call in the order it was made.
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to the given method.
+func (r *recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets every recorded call.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+`
+
+	writer.WriteString(content)
+	writer.Flush()
+	return nil
+}
+
+// writeMockFile creates the mock for a repository or service interface.
+// kind is the interface suffix ("Repository" or "Service") and importLine the aliased import of its package.
+func writeMockFile(filePath, currentFolderName, importLine, modelName, structName, kind string, options ModelOptions) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	key := options.key()
+
+	softDeleteFields := ""
+	softDeleteMethods := ""
+	if options.SoftDelete {
+		softDeleteFields = fmt.Sprintf(`
+	RestoreFunc     func(ctx context.Context, id %[2]s) error
+	FindTrashedFunc func(ctx context.Context) ([]*model.%[1]s, error)
+	ForceDeleteFunc func(ctx context.Context, id %[2]s) error
+`, structName, key.GoType)
+		softDeleteMethods = fmt.Sprintf(`
+func (m *%[1]s%[2]sMock) Restore(ctx context.Context, id %[3]s) error {
+	m.record("Restore", ctx, id)
+	if m.RestoreFunc == nil {
+		return nil
+	}
+	return m.RestoreFunc(ctx, id)
+}
+
+func (m *%[1]s%[2]sMock) FindTrashed(ctx context.Context) ([]*model.%[1]s, error) {
+	m.record("FindTrashed", ctx)
+	if m.FindTrashedFunc == nil {
+		return nil, nil
+	}
+	return m.FindTrashedFunc(ctx)
+}
+
+func (m *%[1]s%[2]sMock) ForceDelete(ctx context.Context, id %[3]s) error {
+	m.record("ForceDelete", ctx, id)
+	if m.ForceDeleteFunc == nil {
+		return nil
+	}
+	return m.ForceDeleteFunc(ctx, id)
+}
+`, structName, kind, key.GoType)
+	}
+
+	// Write the content
+	content := fmt.Sprintf(`package mocks
+
+import (
+	"context"
+
+	"%[2]s/internal/app/domain/model"
+	%[5]s
+%[8]s)
+
+var _ %[1]s%[4]s.%[3]s%[4]s = (*%[3]s%[4]sMock)(nil)
+
+// %[3]s%[4]sMock is a hand-rolled mock of %[1]s%[4]s.%[3]s%[4]s.
+// Set the Func fields to control the results; unset functions return zero values.
+type %[3]s%[4]sMock struct {
+	recorder
+
+	CreateFunc   func(ctx context.Context, %[10]s *model.%[3]s) error
+	UpdateFunc   func(ctx context.Context, id %[9]s, %[10]s *model.%[3]s) error
+	DeleteFunc   func(ctx context.Context, id %[9]s) error
+	FindAllFunc  func(ctx context.Context) ([]*model.%[3]s, error)
+	FindByIdFunc func(ctx context.Context, id %[9]s) (*model.%[3]s, error)
+%[6]s}
+
+func (m *%[3]s%[4]sMock) Create(ctx context.Context, %[10]s *model.%[3]s) error {
+	m.record("Create", ctx, %[10]s)
+	if m.CreateFunc == nil {
+		return nil
+	}
+	return m.CreateFunc(ctx, %[10]s)
+}
+
+func (m *%[3]s%[4]sMock) Update(ctx context.Context, id %[9]s, %[10]s *model.%[3]s) error {
+	m.record("Update", ctx, id, %[10]s)
+	if m.UpdateFunc == nil {
+		return nil
+	}
+	return m.UpdateFunc(ctx, id, %[10]s)
+}
+
+func (m *%[3]s%[4]sMock) Delete(ctx context.Context, id %[9]s) error {
+	m.record("Delete", ctx, id)
+	if m.DeleteFunc == nil {
+		return nil
+	}
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *%[3]s%[4]sMock) FindAll(ctx context.Context) ([]*model.%[3]s, error) {
+	m.record("FindAll", ctx)
+	if m.FindAllFunc == nil {
+		return nil, nil
+	}
+	return m.FindAllFunc(ctx)
+}
+
+func (m *%[3]s%[4]sMock) FindById(ctx context.Context, id %[9]s) (*model.%[3]s, error) {
+	m.record("FindById", ctx, id)
+	if m.FindByIdFunc == nil {
+		return nil, nil
+	}
+	return m.FindByIdFunc(ctx, id)
+}
+%[7]s`, modelName, currentFolderName, structName, kind, importLine, softDeleteFields, softDeleteMethods,
+		key.ImportLine(key.GoType), key.GoType, modelVariable(modelName))
+
+	writer.WriteString(content)
+	writer.Flush()
+	return nil
+}
@@ -5,195 +5,560 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/lucassilveira96/silveirinha/utils"
 )
 
+// ModelSpec describes a model struct to generate, as entered in the prompt or read from a schema.
+type ModelSpec struct {
+	Name       string       // struct name
+	TableName  string       // table name, derived from the struct name when empty
+	IDColumn   string       // column of the ID field, when it is not "id"
+	Fields     []ModelField // fields besides ID and the timestamps
+	Timestamps bool         // adds the CreatedAt and UpdatedAt fields
+	HasMany    []ModelField // has-many relationships, Name is the slice field and Relation the model holding the <Name>Id key
+}
+
+// ModelField is a field of a ModelSpec.
+type ModelField struct {
+	Name        string // Go field name
+	Type        string // Go type, without the pointer of nullable fields
+	Nullable    bool
+	Default     string // GORM default value, empty for none
+	Column      string // column name, when it differs from the one of the naming strategy
+	Relation    string // related model struct name, when the field is a foreign key
+	JSON        string // JSON key, when it differs from the one of the naming strategy
+	Example     string // example value, shown in Swagger and used in the requests of the generated tests
+	Description string // what the field holds, written as its doc comment, Swagger description and column comment
+	Enum        bool   // the field has a string type declared next to the model, its values are Rules.Enum
+	Access      string // "readonly", "writeonly" or "hidden" to leave the field out of the requests or responses
+	Rules       FieldRules
+
+	Unique    bool   // the column is unique, or the composite index it is part of when Index is set
+	Indexed   bool   // the column has an index of its own
+	Index     string // name of the composite index the column is part of, shared with other fields
+	Size      int    // maximum length of strings
+	Precision int    // total digits of decimals, Scale of them after the point
+	Scale     int
+}
+
+// FieldRules are the input rules of a field, checked by the Validate method of the inbound model.
+type FieldRules struct {
+	Required  bool
+	MinLength *int
+	MaxLength *int
+	Minimum   *float64
+	Maximum   *float64
+	Pattern   string
+	Enum      []string
+	Format    string // "email", "uri" or "uuid"
+}
+
+// GoType returns the Go type of the field, a pointer when it is nullable.
+func (f ModelField) GoType() string {
+	if f.Nullable {
+		return "*" + f.Type
+	}
+	return f.Type
+}
+
+// JSONName returns the JSON key of the field.
+func (f ModelField) JSONName() string {
+	if f.JSON != "" {
+		return f.JSON
+	}
+	return jsonKey(f.Name)
+}
+
+// ColumnName returns the column of the field.
+func (f ModelField) ColumnName() string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return columnName(f.Name)
+}
+
+// RelationField returns the name of the struct field holding the related model.
+// Foreign keys named after the relationship ("AuthorId") give "Author", others the model name.
+func (f ModelField) RelationField() string {
+	if name := strings.TrimSuffix(f.Name, "Id"); name != f.Name && name != "" {
+		return name
+	}
+	return f.Relation
+}
+
 // GenerateModel generates Go model files for a given model name.
 // It creates two files: one in the domain layer and another in the inbound layer.
-func GenerateModel(modelName string) error {
-	// Convert the name to camelCase for the file and struct
-	fileName := utils.ToCamelCase(modelName) // Converts the name to camelCase, e.g., "testeLu"
-	structName := strings.Title(fileName)    // Title case for struct (e.g., "TesteLu")
+func GenerateModel(modelName string, options ModelOptions) error {
+	// The struct is named after the PascalCase version of the name, e.g., "TesteLu" for "testeLu"
+	structName := utils.ToPascalCase(modelName)
+	if err := validateModelName(modelName); err != nil {
+		return err
+	}
+	if models, err := ReadModels(modelsDomainPath); err == nil {
+		if _, err := FindModel(models, structName); err == nil {
+			return fmt.Errorf("model %s already exists, edit it with the field commands or run sync", structName)
+		}
+	}
 
-	// Define directories for domain and inbound layers
+	spec := ModelSpec{Name: structName, Fields: promptModelFields(structName, options.key()), Timestamps: true}
+	if err := generateModelLayers(modelName, spec, options); err != nil {
+		return err
+	}
+
+	// Record the new table in a versioned migration
+	err := GenerateMigration("create_" + utils.ToSnakeCase(structName))
+	if err != nil {
+		return fmt.Errorf("error generating migration: %v", err)
+	}
+
+	return nil
+}
+
+// generateModelLayers writes the domain model file of a spec, then every layer built around it.
+func generateModelLayers(modelName string, spec ModelSpec, options ModelOptions) error {
 	domainDir := "internal/app/domain/model"
+	if err := os.MkdirAll(domainDir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating domain directory: %v", err)
+	}
+
+	domainFilePath := fmt.Sprintf("%s/%s.go", domainDir, utils.ToCamelCase(modelName))
+	if err := writeModelFile(domainFilePath, spec, options); err != nil {
+		return fmt.Errorf("error writing domain file: %v", err)
+	}
+	fmt.Printf("Model file generated:\n- %s\n", domainFilePath)
+	printTypeDependencies(spec.Fields)
+	if key := options.key(); key.Import != "" {
+		fmt.Printf("IDs are generated with %s, run `go get %s` if it is not yet a dependency.\n", key.Import, key.Import)
+	}
+
+	return generateLayers(modelName, spec, options)
+}
+
+// generateLayers writes the inbound, validator, outbound and mapper files of a model from its spec,
+// then generates its repository, service, mocks and handler. The domain model file is left untouched.
+func generateLayers(modelName string, spec ModelSpec, options ModelOptions) error {
+	fileName := utils.ToCamelCase(modelName)
+	structName := spec.Name
+
+	// Define directories for inbound and mapper layers
 	inboundDir := "internal/app/transport/inbound"
+	outboundDir := "internal/app/transport/outbound"
 	mapperDir := "internal/app/transport/mapper"
 
 	// Ensure directories exist
-	if err := os.MkdirAll(domainDir, os.ModePerm); err != nil {
-		return fmt.Errorf("error creating domain directory: %v", err)
-	}
 	if err := os.MkdirAll(inboundDir, os.ModePerm); err != nil {
 		return fmt.Errorf("error creating inbound directory: %v", err)
 	}
+	if err := os.MkdirAll(outboundDir, os.ModePerm); err != nil {
+		return fmt.Errorf("error creating outbound directory: %v", err)
+	}
 	if err := os.MkdirAll(mapperDir, os.ModePerm); err != nil {
 		return fmt.Errorf("error creating mapper directory: %v", err)
 	}
 
 	// Create the main files in respective directories
-	domainFilePath := fmt.Sprintf("%s/%s.go", domainDir, fileName)
 	inboundFilePath := fmt.Sprintf("%s/%s.go", inboundDir, fileName)
 	mapperFilePath := fmt.Sprintf("%s/%sMapToModel.go", mapperDir, fileName)
+	outboundFilePath := fmt.Sprintf("%s/%s.go", outboundDir, fileName)
+	outboundMapperFilePath := fmt.Sprintf("%s/%sMapToOutbound.go", mapperDir, fileName)
 
-	// Write domain and inbound model files
-	if err := writeModelFile(domainFilePath, structName, inboundFilePath); err != nil {
-		return fmt.Errorf("error writing domain file: %v", err)
+	// Write the inbound model file
+	if err := writeInboundModelFile(inboundFilePath, spec); err != nil {
+		return fmt.Errorf("error writing inbound file: %v", err)
 	}
 
-	fmt.Printf("Model files generated:\n- %s\n- %s\n", domainFilePath, inboundFilePath)
+	validatorFilePath := fmt.Sprintf("%s/%sValidator.go", inboundDir, fileName)
+	if err := writeValidatorFile(validatorFilePath, spec); err != nil {
+		return fmt.Errorf("error writing validator file: %v", err)
+	}
 
 	// Write the mapper file to map inbound to domain
 	if err := writeMapperFile(mapperFilePath, fileName, structName); err != nil {
 		return fmt.Errorf("error writing mapper file: %v", err)
 	}
 
-	fmt.Printf("Model and Mapper files generated:\n- %s\n- %s\n- %s\n", domainFilePath, inboundFilePath, mapperFilePath)
+	// Write the outbound model file, the body of the responses, and its mapper from domain
+	if err := writeOutboundModelFile(outboundFilePath, spec, options); err != nil {
+		return fmt.Errorf("error writing outbound file: %v", err)
+	}
+	if err := writeOutboundMapperFile(outboundMapperFilePath, structName); err != nil {
+		return fmt.Errorf("error writing mapper file: %v", err)
+	}
 
-	err := GenerateRepository(modelName, structName)
+	fmt.Printf("Inbound, Outbound and Mapper files generated:\n- %s\n- %s\n- %s\n- %s\n- %s\n",
+		inboundFilePath, validatorFilePath, mapperFilePath, outboundFilePath, outboundMapperFilePath)
+
+	err := GenerateRepository(modelName, spec, options)
 	if err != nil {
 		return fmt.Errorf("error generating repository: %v", err)
 	}
 
-	err = GenerateService(modelName, structName)
+	err = GenerateService(modelName, structName, options)
 	if err != nil {
 		return fmt.Errorf("error generating service: %v", err)
 	}
 
-	err = GenerateHandler(modelName, structName)
+	err = GenerateMocks(modelName, structName, options)
 	if err != nil {
-		return fmt.Errorf("error generating service: %v", err)
+		return fmt.Errorf("error generating mocks: %v", err)
 	}
 
-	return nil
-}
-
-// writeModelFile creates a model file and writes its struct definition interactively.
-// Users specify attributes and their properties.
-func writeModelFile(filePath, structName, inboundFilePath string) error {
-	// Open file for writing
-	file, err := os.Create(filePath)
+	err = GenerateHandler(modelName, spec, options)
 	if err != nil {
-		return fmt.Errorf("error creating file: %v", err)
+		return fmt.Errorf("error generating service: %v", err)
 	}
-	defer file.Close()
 
-	writer := bufio.NewWriter(file)
+	return nil
+}
 
-	// Write package declaration and imports
-	writer.WriteString("package model\n\n")
-	writer.WriteString(`import "time"` + "\n\n")
-
-	// Start defining the struct
-	writer.WriteString(fmt.Sprintf("type %s struct {\n", structName))
-	writer.WriteString("\tID uint `gorm:\"primaryKey;autoIncrement\" json:\"id\"`\n")
+// promptModelFields asks the user for the attributes and relationships of a model keyed by key.
+// Invalid names are explained and the question is asked again.
+func promptModelFields(structName string, key KeyType) []ModelField {
+	var fields []ModelField
 
 	// Prompt user to add attributes
 	for {
 		fmt.Print("Add an attribute to the struct? (y/n): ")
-		var choice string
-		fmt.Scanln(&choice)
+		choice := readWord()
 
 		if strings.ToLower(choice) != "y" {
 			break
 		}
 
-		var attrName, attrType, defaultValue string
-		var nullable, hasDefault bool
-
 		// Collect attribute name
 		fmt.Print("Attribute name: ")
-		fmt.Scanln(&attrName)
+		attrName := readWord()
+		if err := validateFieldName(structName, attrName, fields); err != nil {
+			fmt.Println(err)
+			continue
+		}
 
-		// Collect attribute type
-		attrType = selectType()
+		// Collect attribute type, with the rules fields of that type start with
+		fieldType := selectType(enumFieldType)
+		field := ModelField{Name: utils.ToPascalCase(attrName), Type: fieldType.GoType, Rules: parseRulesTag(fieldType.Rules)}
+
+		// Enums ask for their values, and get a type of their own
+		var values []string
+		if fieldType.GoType == enumFieldType.GoType {
+			fmt.Print("Enum values (comma separated): ")
+			var err error
+			if values, err = parseEnumValues(readLine()); err != nil {
+				fmt.Println(err)
+				continue
+			}
+		}
+
+		// The JSON key and column follow the naming strategies unless they are given
+		fmt.Printf("JSON key (empty for %s): ", field.JSONName())
+		field.JSON = readWord()
+		fmt.Printf("Column name (empty for %s): ", field.ColumnName())
+		field.Column = readWord()
+		if err := validateFieldNaming(field, fields); err != nil {
+			fmt.Println(err)
+			continue
+		}
 
 		// Determine if the attribute is nullable
 		fmt.Print("Is it nullable? (y/n): ")
-		fmt.Scanln(&choice)
-		nullable = strings.ToLower(choice) == "y"
-		if nullable {
-			attrType = "*" + attrType
-		}
+		choice = readWord()
+		field.Nullable = strings.ToLower(choice) == "y"
 
-		// Determine if the attribute has a default value
-		if !nullable {
+		// Determine if the attribute has a default value, read as a whole line as it can have spaces
+		if !field.Nullable {
 			fmt.Print("Has a default value? (y/n): ")
-			fmt.Scanln(&choice)
-			hasDefault = strings.ToLower(choice) == "y"
-			if hasDefault {
-				fmt.Print("Default value: ")
-				fmt.Scanln(&defaultValue)
+			choice = readWord()
+			if strings.ToLower(choice) == "y" {
+				fmt.Print("Default value (a value of the type, or an expression like CURRENT_TIMESTAMP or now()): ")
+				field.Default = readLine()
+				if err := validateDefault(&field); err != nil {
+					fmt.Println(err)
+					continue
+				}
 			}
 		}
 
-		// Add attribute definition
-		jsonName := utils.ToSnakeCase(attrName)
-		gormTag := ""
-		if hasDefault {
-			gormTag = fmt.Sprintf(`gorm:"default:%s"`, defaultValue)
-		} else if !nullable {
-			gormTag = `gorm:"not null"`
+		// The description and example document the attribute in Swagger, the description in the database too
+		fmt.Print("Description (empty for none): ")
+		field.Description = readLine()
+		if err := validateDescription(field); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Print("Example value (empty for none): ")
+		field.Example = readLine()
+
+		// Server-managed fields are read-only, secrets write-only and internal fields hidden from the API
+		fmt.Print("Access, readonly, writeonly or hidden (empty for read and write): ")
+		field.Access = readWord()
+		if err := validateAccess(field); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if err := validateRequired(field); err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		// Strings can be bounded and decimals given their precision
+		if field.Type == "string" && values == nil {
+			fmt.Print("Maximum length (empty for none): ")
+			size := readWord()
+			if size != "" {
+				field.Size, _ = strconv.Atoi(size)
+				if field.Size <= 0 {
+					fmt.Println("The maximum length has to be a positive number")
+					continue
+				}
+			}
+		}
+		if _, ok := precisionType(fieldType.SQLTypes[projectDialect()], 0, 0); ok {
+			fmt.Print("Precision and scale, like 10,2 (empty for the default): ")
+			precision := readWord()
+			if precision != "" {
+				if _, err := fmt.Sscanf(precision, "%d,%d", &field.Precision, &field.Scale); err != nil {
+					fmt.Println("The precision and scale have to be two numbers, like 10,2")
+					continue
+				}
+			}
 		}
-		writer.WriteString(fmt.Sprintf("\t%s %s `%s json:\"%s\"`\n",
-			utils.ToPascalCase(attrName), attrType, gormTag, jsonName))
 
-		// Also add the attribute to the inbound model file
-		writeInboundModelFile(inboundFilePath, structName, attrName, attrType, jsonName)
+		// Determine how the attribute is indexed, a name sharing an index with other attributes
+		fmt.Print("Is it unique? (y/n): ")
+		choice = readWord()
+		field.Unique = strings.ToLower(choice) == "y"
+		if !field.Unique {
+			fmt.Print("Is it indexed? (y/n): ")
+			choice = readWord()
+			field.Indexed = strings.ToLower(choice) == "y"
+		}
+		if field.Unique || field.Indexed {
+			fmt.Print("Composite index name, shared with other attributes (empty for none): ")
+			field.Index = readWord()
+			if field.Index != "" {
+				field.Indexed = false
+			}
+		}
+		if err := validateColumnOptions(&field); err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		if values != nil {
+			var err error
+			if field, err = enumField(structName, field, values); err != nil {
+				fmt.Println(err)
+				continue
+			}
+		}
+		if err := validateExample(&field); err != nil {
+			fmt.Println(err)
+			continue
+		}
+
+		fields = append(fields, field)
 	}
 
 	// Prompt user to add relationships
 	for {
 		fmt.Print("Would you like to add a relationship? (y/n): ")
-		var choice string
-		fmt.Scanln(&choice)
+		choice := readWord()
 
 		if strings.ToLower(choice) != "y" {
 			break
 		}
 
-		var relatedModel string
 		fmt.Print("Enter the name of the related model: ")
-		fmt.Scanln(&relatedModel)
+		relatedModel := readWord()
+		if err := validateRelationName(structName, relatedModel, fields); err != nil {
+			fmt.Println(err)
+			continue
+		}
 
+		// The foreign key has the type of the ID of the related model
 		relationshipName := utils.ToPascalCase(relatedModel)
-		relationshipNameSnake := utils.ToSnakeCase(relatedModel)
-		relatedField := fmt.Sprintf("%sId", relationshipName)
-		relatedFieldSnake := utils.ToSnakeCase(relatedField)
+		keyType := key.GoType
+		if relationshipName != structName {
+			keyType = relationKeyType(relationshipName, key)
+		}
+		fields = append(fields, ModelField{Name: relationshipName + "Id", Type: keyType, Relation: relationshipName})
+	}
 
-		// Add the foreign key field
-		writer.WriteString(fmt.Sprintf("\t%s uint `json:\"%s\"`\n", relatedField, relatedFieldSnake))
+	return fields
+}
 
-		// Add the relationship
-		writer.WriteString(fmt.Sprintf("\t%s %s `gorm:\"foreignKey:%s\" json:\"%s\"`\n",
-			relationshipName, relationshipName, relatedField, relationshipNameSnake))
+// writeModelFile creates the domain model file of a spec.
+// Soft-deleted models get a gorm.DeletedAt field so GORM scopes every query to live rows.
+func writeModelFile(filePath string, spec ModelSpec, options ModelOptions) error {
+	// Open file for writing
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
 
-		// Also add the relationship to the inbound model file
-		writeInboundModelFile(inboundFilePath, structName, relatedField, "uint", relatedFieldSnake)
+	writer := bufio.NewWriter(file)
+
+	// Write package declaration and imports
+	key := options.key()
+	std, external := fieldImports(spec.Fields)
+	if spec.Timestamps && !slices.Contains(std, "time") {
+		std = append([]string{"time"}, std...)
+	}
+	if options.SoftDelete || key.New != "" {
+		external = append(external, "gorm.io/gorm")
 	}
+	if key.Import != "" && !slices.Contains(external, key.Import) {
+		external = append(external, key.Import)
+	}
+	sort.Strings(external)
+	if slices.ContainsFunc(spec.Fields, func(field ModelField) bool { return field.Enum }) {
+		std = append(std, "encoding/json", "fmt", "slices")
+		sort.Strings(std)
+	}
+	writer.WriteString("package model\n\n")
+	writer.WriteString(importBlock(std, external))
 
-	writer.WriteString("\tCreatedAt time.Time `gorm:\"autoCreateTime;not null\" json:\"created_at\"`\n")
-	writer.WriteString("\tUpdatedAt time.Time `gorm:\"autoUpdateTime;not null\" json:\"updated_at\"`\n")
-	writer.WriteString("\tDeletedAt *time.Time `gorm:\"index\" json:\"deleted_at\"`\n")
+	// Start defining the struct
+	writer.WriteString(fmt.Sprintf("type %s struct {\n", spec.Name))
+	idTag := key.IDTag()
+	if spec.idColumn() != "id" {
+		idTag += ";column:" + spec.idColumn()
+	}
+	writer.WriteString(fmt.Sprintf("\tID %s `gorm:\"%s\" json:\"%s\"`\n", key.GoType, idTag, jsonKey("ID")))
+
+	for _, field := range spec.Fields {
+		writer.WriteString(fieldDoc(field))
+		writer.WriteString(fmt.Sprintf("\t%s %s `%s`\n", field.Name, field.GoType(), modelFieldTag(field)))
+
+		// Foreign keys are followed by the relationship they point to, a pointer when it is the model itself
+		if field.Relation != "" {
+			relationType := field.Relation
+			if relationType == spec.Name {
+				relationType = "*" + relationType
+			}
+			writer.WriteString(fmt.Sprintf("\t%s %s `gorm:\"foreignKey:%s\" json:\"%s\"`\n",
+				field.RelationField(), relationType, field.Name, jsonKey(field.RelationField())))
+		}
+	}
+
+	// Has-many relationships are filled through the foreign key the related model holds
+	for _, relation := range spec.HasMany {
+		writer.WriteString(fmt.Sprintf("\t%s []%s `gorm:\"foreignKey:%sId\" json:\"%s\"`\n",
+			relation.Name, relation.Relation, spec.Name, relation.JSONName()))
+	}
+
+	if spec.Timestamps {
+		writer.WriteString(fmt.Sprintf("\tCreatedAt time.Time `gorm:\"autoCreateTime;not null\" json:\"%s\"`\n", jsonKey("CreatedAt")))
+		writer.WriteString(fmt.Sprintf("\tUpdatedAt time.Time `gorm:\"autoUpdateTime;not null\" json:\"%s\"`\n", jsonKey("UpdatedAt")))
+	}
+	if options.SoftDelete {
+		writer.WriteString(fmt.Sprintf("\tDeletedAt gorm.DeletedAt `gorm:\"index\" json:\"%s\"`\n", jsonKey("DeletedAt")))
+	}
 
 	// Close the struct definition
 	writer.WriteString("}\n\n")
 
 	// Add TableName method for GORM
-	writer.WriteString(fmt.Sprintf("func (%s) TableName() string {\n", structName))
-	writer.WriteString(fmt.Sprintf("\treturn \"%s\"\n", utils.ToSnakeCase(structName)))
+	writer.WriteString(fmt.Sprintf("func (%s) TableName() string {\n", spec.Name))
+	writer.WriteString(fmt.Sprintf("\treturn \"%s\"\n", modelTableName(spec)))
 	writer.WriteString("}\n")
+	writer.WriteString(key.beforeCreateHook(spec.Name))
+
+	// Enums are declared next to the model using them
+	for _, field := range spec.Fields {
+		if field.Enum {
+			writer.WriteString(enumDeclaration(field))
+		}
+	}
 
 	// Flush writer buffer
 	writer.Flush()
 	return nil
 }
 
-// writeInboundModelFile creates the inbound model file (without ID, date fields, GORM tags, and TableName function)
-func writeInboundModelFile(filePath, structName, attrName, attrType, jsonName string) error {
+// idColumn returns the column of the ID field of a spec.
+func (s ModelSpec) idColumn() string {
+	if s.IDColumn != "" {
+		return s.IDColumn
+	}
+	return "id"
+}
+
+// modelTableName returns the table the TableName method of a generated model returns: the table of
+// the spec, or else the snake_case struct name.
+func modelTableName(spec ModelSpec) string {
+	if spec.TableName != "" {
+		return spec.TableName
+	}
+	return utils.ToSnakeCase(spec.Name)
+}
+
+// modelFieldTag returns the struct tag of a field of the domain model, without its backquotes.
+func modelFieldTag(field ModelField) string {
+	var settings []string
+	if column := field.ColumnName(); column != toColumnName(field.Name) {
+		settings = append(settings, "column:"+column)
+	}
+
+	// Registered types get the SQL type of the project dialect and the settings they need
+	fieldType, _ := LookupFieldType(field.Type)
+	if sqlType := fieldType.SQLTypes[projectDialect()]; sqlType != "" {
+		if field.Precision > 0 {
+			sqlType, _ = precisionType(sqlType, field.Precision, field.Scale)
+		}
+		settings = append(settings, "type:"+sqlType)
+	}
+	if field.Size > 0 {
+		settings = append(settings, fmt.Sprintf("size:%d", field.Size))
+	}
+	if fieldType.GormTag != "" {
+		settings = append(settings, fieldType.GormTag)
+	}
+	if field.Enum {
+		settings = append(settings, "check:"+enumCheck(field.ColumnName(), field.Rules.Enum))
+	}
+	settings = append(settings, indexSettings(field)...)
+	if field.Description != "" {
+		settings = append(settings, "comment:"+strings.ReplaceAll(field.Description, ";", `\;`))
+	}
+
+	if field.Default != "" {
+		settings = append(settings, "default:"+gormDefault(field))
+	} else if !field.Nullable && field.Relation == "" {
+		if fieldType.Default != "" {
+			settings = append(settings, "default:"+fieldType.Default)
+		}
+		settings = append(settings, "not null")
+	}
+
+	tag := ""
+	if len(settings) > 0 {
+		tag = "gorm:" + strconv.Quote(strings.Join(settings, ";")) + " "
+	}
+	if field.Access == "hidden" {
+		tag += `json:"-"`
+	} else {
+		tag += fmt.Sprintf(`json:"%s"`, field.JSONName()) + swaggerTag(field)
+	}
+	if field.Access == "readonly" || field.Access == "writeonly" {
+		tag += fmt.Sprintf(` access:"%s"`, field.Access)
+	}
+
+	// The rules are kept on the domain model, so the validator can be regenerated from it
+	if rules := rulesTag(field.Rules); rules != "" {
+		tag += " rules:" + strconv.Quote(rules)
+	}
+	return tag
+}
+
+// writeInboundModelFile creates the inbound model file (without ID, date fields, read-only and hidden fields,
+// GORM tags, and TableName function)
+func writeInboundModelFile(filePath string, spec ModelSpec) error {
 	// Create the file in the inbound directory
 	file, err := os.Create(filePath)
 	if err != nil {
@@ -204,13 +569,28 @@ func writeInboundModelFile(filePath, structName, attrName, attrType, jsonName st
 	writer := bufio.NewWriter(file)
 
 	// Write the package declaration for inbound model
+	// Enums are the types of the model package
+	fields := spec.inboundFields()
+	std, external := fieldImports(fields)
+	if slices.ContainsFunc(fields, func(field ModelField) bool { return field.Enum }) {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("error getting current working directory: %v", err)
+		}
+		external = append(external, filepath.Base(currentDir)+"/internal/app/domain/model")
+		sort.Strings(external)
+	}
 	writer.WriteString("package inbound\n\n")
+	writer.WriteString(importBlock(std, external))
 
 	// Start defining the struct (same as the model, without ID and date fields)
-	writer.WriteString(fmt.Sprintf("type %s struct {\n", utils.ToPascalCase(structName)))
+	writer.WriteString(fmt.Sprintf("type %s struct {\n", spec.Name))
 
-	// Add the field to the inbound struct with JSON tags
-	writer.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n", utils.ToPascalCase(attrName), attrType, jsonName))
+	// Add the fields to the inbound struct with JSON tags
+	for _, field := range fields {
+		writer.WriteString(fieldDoc(field))
+		writer.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"%s`\n", field.Name, transportType(field), field.JSONName(), swaggerTag(field)))
+	}
 
 	// Close the struct definition
 	writer.WriteString("}\n")
@@ -220,6 +600,15 @@ func writeInboundModelFile(filePath, structName, attrName, attrType, jsonName st
 	return nil
 }
 
+// transportType returns the Go type of a field in the inbound and outbound models, where enums are
+// the types of the model package.
+func transportType(field ModelField) string {
+	if field.Enum {
+		return strings.Replace(field.GoType(), field.Type, "model."+field.Type, 1)
+	}
+	return field.GoType()
+}
+
 // writeMapperFile generates the mapper file to map from inbound model to domain model
 func writeMapperFile(filePath, fileName, structName string) error {
 	// Get the current working directory
@@ -276,91 +665,55 @@ func writeMapperFile(filePath, fileName, structName string) error {
 	return nil
 }
 
-// ShowGoTypes lists the supported Go types for attributes.
+// enumFieldType is the choice of the type menu declaring an enum, whose values are asked next.
+var enumFieldType = FieldType{GoType: "enum"}
+
+// ShowGoTypes lists the types attributes can be declared with.
 // It displays a menu for user selection during attribute definition.
-func ShowGoTypes() {
+func ShowGoTypes(available []FieldType) {
 	fmt.Println("Choose a type for the attribute:")
-	fmt.Println("1) int")
-	fmt.Println("2) uint")
-	fmt.Println("3) int8")
-	fmt.Println("4) uint8")
-	fmt.Println("5) int16")
-	fmt.Println("6) uint16")
-	fmt.Println("7) int32")
-	fmt.Println("8) uint32")
-	fmt.Println("9) int64")
-	fmt.Println("10) uint64")
-	fmt.Println("11) string")
-	fmt.Println("12) float32")
-	fmt.Println("13) float64")
-	fmt.Println("14) complex64")
-	fmt.Println("15) complex128")
-	fmt.Println("16) bool")
-	fmt.Println("17) byte")
-	fmt.Println("18) rune")
-	fmt.Println("19) time.Time")
-	fmt.Println("20) []byte")
+	for i, fieldType := range available {
+		fmt.Printf("%d) %s\n", i+1, fieldType.GoType)
+	}
+}
+
+// stdin is the reader shared by the prompts, so the answers it buffers, like piped ones, are not lost
+// between two prompts.
+var stdin = bufio.NewReader(os.Stdin)
+
+// readLine reads a whole line of the standard input, spaces included, without its line break.
+func readLine() string {
+	line, _ := stdin.ReadString('\n')
+	return strings.TrimRight(line, "\r\n")
 }
 
-// selectType allows users to select a type from a predefined list.
-func selectType() string {
-	ShowGoTypes()
+// readWord reads a line of the standard input and returns its first word, or an empty string.
+func readWord() string {
+	words := strings.Fields(readLine())
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
+}
+
+// selectType allows users to select one of the registered types the project dialect can store,
+// or one of the extra choices listed after them.
+func selectType(extra ...FieldType) FieldType {
+	available := append(AvailableFieldTypes(projectDialect()), extra...)
+	ShowGoTypes(available)
 
-	reader := bufio.NewReader(os.Stdin)
 	for {
 		fmt.Print("Enter the number corresponding to the type: ")
-		input, err := reader.ReadString('\n')
+		input, err := stdin.ReadString('\n')
 		if err != nil {
 			fmt.Println("Invalid input. Please try again.")
 			continue
 		}
 		choice, err := strconv.Atoi(strings.TrimSpace(input))
-		if err != nil || choice < 1 || choice > 20 {
+		if err != nil || choice < 1 || choice > len(available) {
 			fmt.Println("Invalid choice. Please select a valid number.")
 			continue
 		}
-
-		switch choice {
-		case 1:
-			return "int"
-		case 2:
-			return "uint"
-		case 3:
-			return "int8"
-		case 4:
-			return "uint8"
-		case 5:
-			return "int16"
-		case 6:
-			return "uint16"
-		case 7:
-			return "int32"
-		case 8:
-			return "uint32"
-		case 9:
-			return "int64"
-		case 10:
-			return "uint64"
-		case 11:
-			return "string"
-		case 12:
-			return "float32"
-		case 13:
-			return "float64"
-		case 14:
-			return "complex64"
-		case 15:
-			return "complex128"
-		case 16:
-			return "bool"
-		case 17:
-			return "byte"
-		case 18:
-			return "rune"
-		case 19:
-			return "time.Time"
-		case 20:
-			return "[]byte"
-		}
+		return available[choice-1]
 	}
 }
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+)
+
+// NamingConfig holds the strategies spelling the JSON keys and the columns of fields from their Go names.
+type NamingConfig struct {
+	// JSON spells the JSON keys: snake_case (the default), camelCase, PascalCase or kebab-case.
+	JSON string `json:"json,omitempty"`
+
+	// Column spells the columns: snake_case (the default, the one of GORM), camelCase or PascalCase.
+	Column string `json:"column,omitempty"`
+}
+
+// namingStrategies lists the strategies by name. Columns cannot be spelled in kebab-case.
+var namingStrategies = map[string]func(string) string{
+	"snake_case": inflection.Underscore,
+	"camelCase":  inflection.Camelize,
+	"PascalCase": inflection.Pascalize,
+	"kebab-case": inflection.Dasherize,
+}
+
+var (
+	// jsonKeyPattern matches the JSON keys fields can be given, which end up in struct tags.
+	jsonKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+	// columnPattern matches the columns fields can be given, used as SQL identifiers.
+	columnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// validate checks the strategies of a configuration.
+func (n NamingConfig) validate() error {
+	for setting, strategy := range map[string]string{"json": n.JSON, "column": n.Column} {
+		if strategy == "" {
+			continue
+		}
+		if _, ok := namingStrategies[strategy]; !ok || (setting == "column" && strategy == "kebab-case") {
+			names := make([]string, 0, len(namingStrategies))
+			for name := range namingStrategies {
+				if setting == "json" || name != "kebab-case" {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			return fmt.Errorf("unsupported %s naming strategy %q, supported strategies are: %s", setting, strategy, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// projectNaming returns the naming strategies of the project, snake_case when it sets none.
+func projectNaming() NamingConfig {
+	naming := NamingConfig{JSON: "snake_case", Column: "snake_case"}
+	config, err := LoadProjectConfig()
+	if err != nil || config.Naming == nil {
+		return naming
+	}
+	if config.Naming.JSON != "" {
+		naming.JSON = config.Naming.JSON
+	}
+	if config.Naming.Column != "" {
+		naming.Column = config.Naming.Column
+	}
+	return naming
+}
+
+// jsonKey returns the JSON key of a Go name, like "createdAt" for "CreatedAt" in camelCase projects.
+func jsonKey(name string) string {
+	return namingStrategies[projectNaming().JSON](name)
+}
+
+// columnName returns the column of a Go field name, like "createdAt" for "CreatedAt" in camelCase projects.
+func columnName(name string) string {
+	return namingStrategies[projectNaming().Column](name)
+}
+
+// validateFieldNaming checks the JSON key and column a field is given, and that they are not used by
+// the fields declared before it.
+func validateFieldNaming(field ModelField, fields []ModelField) error {
+	if field.JSON != "" && !jsonKeyPattern.MatchString(field.JSON) {
+		return fmt.Errorf("invalid JSON key %q, use letters, digits, dots, dashes and underscores", field.JSON)
+	}
+	if field.Column != "" && !columnPattern.MatchString(field.Column) {
+		return fmt.Errorf("invalid column %q, use letters, digits and underscores", field.Column)
+	}
+	for _, declared := range fields {
+		if declared.JSONName() == field.JSONName() {
+			return fmt.Errorf("the JSON key %s is already used by %s", field.JSONName(), declared.Name)
+		}
+		if strings.EqualFold(declared.ColumnName(), field.ColumnName()) {
+			return fmt.Errorf("the column %s is already used by %s", field.ColumnName(), declared.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,605 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIDocument is the part of an OpenAPI 3 document the generator reads.
+type openAPIDocument struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// openAPISchema is a JSON schema of an OpenAPI document.
+type openAPISchema struct {
+	Ref         string                    `json:"$ref"`
+	Type        interface{}               `json:"type"` // a string, or a list of types in OpenAPI 3.1
+	Format      string                    `json:"format"`
+	Properties  map[string]*openAPISchema `json:"properties"`
+	Required    []string                  `json:"required"`
+	Items       *openAPISchema            `json:"items"`
+	AllOf       []*openAPISchema          `json:"allOf"`
+	Enum        []interface{}             `json:"enum"`
+	MinLength   *int                      `json:"minLength"`
+	MaxLength   *int                      `json:"maxLength"`
+	Minimum     *float64                  `json:"minimum"`
+	Maximum     *float64                  `json:"maximum"`
+	Pattern     string                    `json:"pattern"`
+	Nullable    bool                      `json:"nullable"`
+	Default     interface{}               `json:"default"`
+	Example     interface{}               `json:"example"`
+	Description string                    `json:"description"`
+	ReadOnly    bool                      `json:"readOnly"`
+	WriteOnly   bool                      `json:"writeOnly"`
+}
+
+// openAPIOperation is an operation of a path of an OpenAPI document.
+type openAPIOperation struct {
+	Summary     string   `json:"summary"`
+	Tags        []string `json:"tags"`
+	RequestBody *struct {
+		Content map[string]openAPIMediaType `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]openAPIMediaType `json:"content"`
+	} `json:"responses"`
+}
+
+// openAPIMediaType is the content of a request or response for a media type.
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+// openAPIPathParameter matches the path parameters of an OpenAPI path, like "{id}".
+var openAPIPathParameter = regexp.MustCompile(`\{([^}]+)\}`)
+
+// ImportOpenAPI generates a model for every schema of an OpenAPI 3 document served by CRUD
+// operations. The handler routes and success status codes follow the document, and the
+// schema constraints become the rules of the inbound model validator.
+func ImportOpenAPI(path string) error {
+	document, err := loadOpenAPIDocument(path)
+	if err != nil {
+		return err
+	}
+
+	routes := openAPIRoutes(document)
+	if len(routes) == 0 {
+		return fmt.Errorf("no CRUD operation of a components schema found in %s", path)
+	}
+
+	var names []string
+	for name := range document.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Schemas that give no valid model name are left out, and the references to them with them
+	invalid := map[string]bool{}
+	for _, name := range names {
+		if _, ok := routes[name]; !ok {
+			continue
+		}
+		if err := validateModelName(columnFieldName(name)); err != nil {
+			fmt.Printf("Skipping schema %s: %v\n", name, err)
+			invalid[name] = true
+			delete(routes, name)
+		}
+	}
+
+	generated := 0
+	for _, name := range names {
+		if invalid[name] {
+			continue
+		}
+		handlerRoutes, ok := routes[name]
+		if !ok {
+			fmt.Printf("Skipping schema %s: no CRUD operation uses it\n", name)
+			continue
+		}
+
+		spec, options := openAPIModelSpec(document, name, routes)
+		options.Routes = handlerRoutes
+
+		modelName := utils.ToCamelCase(spec.Name)
+		if _, err := os.Stat(filepath.Join(modelsDomainPath, modelName+".go")); err == nil {
+			fmt.Printf("Skipping schema %s: model %s already exists\n", name, spec.Name)
+			continue
+		}
+
+		if err := generateModelLayers(modelName, spec, options); err != nil {
+			return fmt.Errorf("error generating model for schema %s: %v", name, err)
+		}
+		generated++
+	}
+
+	if generated == 0 {
+		return nil
+	}
+
+	// Record the new tables in a versioned migration
+	if err := GenerateMigration("import_openapi"); err != nil {
+		return fmt.Errorf("error generating migration: %v", err)
+	}
+	return nil
+}
+
+// loadOpenAPIDocument reads a JSON or YAML OpenAPI document.
+func loadOpenAPIDocument(path string) (*openAPIDocument, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	// YAML is a superset of JSON, so both go through the YAML decoder
+	var raw interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	content, err = json.Marshal(normalizeYAML(raw))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	document := &openAPIDocument{}
+	if err := json.Unmarshal(content, document); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	return document, nil
+}
+
+// normalizeYAML turns the maps decoded from YAML into maps with string keys,
+// since keys like response codes are decoded as integers.
+func normalizeYAML(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = normalizeYAML(item)
+		}
+		return value
+	case map[interface{}]interface{}:
+		result := map[string]interface{}{}
+		for key, item := range value {
+			result[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+		return result
+	case []interface{}:
+		for i, item := range value {
+			value[i] = normalizeYAML(item)
+		}
+	}
+	return value
+}
+
+// openAPIRoutes maps the CRUD operations of the document paths to the schemas they serve.
+// Collection paths have no parameter and item paths end with a single one, like "/pets/{petId}".
+func openAPIRoutes(document *openAPIDocument) map[string]*HandlerRoutes {
+	routes := map[string]*HandlerRoutes{}
+	route := func(schema string) *HandlerRoutes {
+		if routes[schema] == nil {
+			routes[schema] = &HandlerRoutes{}
+		}
+		return routes[schema]
+	}
+
+	var paths []string
+	for path := range document.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	type pendingDelete struct {
+		path string
+		op   *HandlerOperation
+	}
+	var deletes []pendingDelete
+
+	for _, path := range paths {
+		parameters := openAPIPathParameter.FindAllStringIndex(path, -1)
+		isItem := len(parameters) == 1 && parameters[0][1] == len(path)
+		if len(parameters) > 0 && !isItem {
+			fmt.Printf("Skipping path %s: only collection and item paths are generated\n", path)
+			continue
+		}
+
+		for _, method := range []string{"get", "post", "put", "patch", "delete"} {
+			raw, ok := document.Paths[path][method]
+			if !ok {
+				continue
+			}
+			var operation openAPIOperation
+			if err := json.Unmarshal(raw, &operation); err != nil {
+				fmt.Printf("Skipping %s %s: %v\n", strings.ToUpper(method), path, err)
+				continue
+			}
+
+			status, response := operation.success()
+			op := &HandlerOperation{
+				Method:  strings.ToUpper(method),
+				Path:    openAPIPathParameter.ReplaceAllString(path, ":$1"),
+				Status:  status,
+				Summary: operation.Summary,
+			}
+			if len(operation.Tags) > 0 {
+				op.Tag = operation.Tags[0]
+			}
+
+			body := ""
+			if operation.RequestBody != nil {
+				body = schemaRef(mediaSchema(operation.RequestBody.Content))
+			}
+
+			var target **HandlerOperation
+			schema := ""
+			switch {
+			case method == "get" && !isItem && response != nil && response.Items != nil:
+				schema = schemaRef(response.Items)
+				target = &route(schema).List
+			case method == "get" && isItem:
+				schema = schemaRef(response)
+				target = &route(schema).Get
+			case method == "post" && !isItem:
+				schema = firstNonEmpty(schemaRef(response), body)
+				target = &route(schema).Create
+			case (method == "put" || method == "patch") && isItem:
+				schema = firstNonEmpty(schemaRef(response), body)
+				target = &route(schema).Update
+			case method == "delete" && isItem:
+				deletes = append(deletes, pendingDelete{path: op.Path, op: op})
+				continue
+			}
+
+			if schema == "" || document.Components.Schemas[schema] == nil {
+				delete(routes, schema)
+				fmt.Printf("Skipping %s %s: it does not serve a components schema\n", op.Method, path)
+				continue
+			}
+			if *target != nil {
+				fmt.Printf("Skipping %s %s: schema %s already has this operation\n", op.Method, path, schema)
+				continue
+			}
+			*target = op
+		}
+	}
+
+	// Deletes don't carry a schema, they belong to the schema served at the same item path
+	for _, pending := range deletes {
+		matched := false
+		for _, schemaRoutes := range routes {
+			for _, op := range []*HandlerOperation{schemaRoutes.Get, schemaRoutes.Update} {
+				if op != nil && op.Path == pending.path && schemaRoutes.Delete == nil && !matched {
+					schemaRoutes.Delete = pending.op
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			fmt.Printf("Skipping DELETE %s: no schema is served at this path\n", pending.path)
+		}
+	}
+	return routes
+}
+
+// success returns the lowest 2xx status of the operation with its response schema.
+func (operation openAPIOperation) success() (int, *openAPISchema) {
+	status := 0
+	var schema *openAPISchema
+	for code, response := range operation.Responses {
+		value, err := strconv.Atoi(code)
+		if err != nil || value < 200 || value > 299 || (status != 0 && value > status) {
+			continue
+		}
+		status = value
+		schema = mediaSchema(response.Content)
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return status, schema
+}
+
+// mediaSchema returns the JSON schema of a content map, or the first one when there is no JSON.
+func mediaSchema(content map[string]openAPIMediaType) *openAPISchema {
+	if media, ok := content["application/json"]; ok {
+		return media.Schema
+	}
+	var types []string
+	for mediaType := range content {
+		types = append(types, mediaType)
+	}
+	sort.Strings(types)
+	if len(types) > 0 {
+		return content[types[0]].Schema
+	}
+	return nil
+}
+
+// schemaRef returns the components schema name a schema points to.
+func schemaRef(schema *openAPISchema) string {
+	if schema == nil {
+		return ""
+	}
+	return strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+}
+
+// firstNonEmpty returns the first value that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// types returns the declared types of a schema, with "null" for OpenAPI 3.1 nullable types.
+func (schema *openAPISchema) types() []string {
+	switch value := schema.Type.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var types []string
+		for _, item := range value {
+			types = append(types, fmt.Sprint(item))
+		}
+		return types
+	}
+	return nil
+}
+
+// properties returns the properties and required names of a schema, merging its allOf parts.
+func (document *openAPIDocument) properties(schema *openAPISchema) (map[string]*openAPISchema, map[string]bool) {
+	properties := map[string]*openAPISchema{}
+	required := map[string]bool{}
+	if ref := schemaRef(schema); ref != "" {
+		if resolved := document.Components.Schemas[ref]; resolved != nil && resolved != schema {
+			return document.properties(resolved)
+		}
+		return properties, required
+	}
+
+	for _, part := range schema.AllOf {
+		partProperties, partRequired := document.properties(part)
+		for name, property := range partProperties {
+			properties[name] = property
+		}
+		for name := range partRequired {
+			required[name] = true
+		}
+	}
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	return properties, required
+}
+
+// openAPIModelSpec maps a components schema to the model spec and options used to generate it.
+// Properties pointing to another generated schema become relationships.
+func openAPIModelSpec(document *openAPIDocument, name string, routes map[string]*HandlerRoutes) (ModelSpec, ModelOptions) {
+	spec := ModelSpec{Name: columnFieldName(name)}
+	options := ModelOptions{Key: document.keyType(name)}
+
+	properties, required := document.properties(document.Components.Schemas[name])
+	var propertyNames []string
+	for propertyName := range properties {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+
+	// Timestamps and soft delete are only generated when the schema has their properties
+	has := func(names ...string) bool {
+		for _, candidate := range names {
+			if _, ok := properties[candidate]; ok {
+				return true
+			}
+		}
+		return false
+	}
+	spec.Timestamps = has("created_at", "createdAt") && has("updated_at", "updatedAt")
+	options.SoftDelete = has("deleted_at", "deletedAt")
+
+	for _, propertyName := range propertyNames {
+		property := properties[propertyName]
+		switch propertyName {
+		case "id", "ID", "Id":
+			continue
+		case "created_at", "createdAt", "updated_at", "updatedAt":
+			if spec.Timestamps {
+				continue
+			}
+		case "deleted_at", "deletedAt":
+			continue
+		}
+
+		field := ModelField{Name: columnFieldName(propertyName)}
+
+		// References to other generated schemas are stored as a foreign key
+		if ref := schemaRef(property); ref != "" {
+			if _, generated := routes[ref]; !generated {
+				fmt.Printf("Skipping property %s.%s: schema %s is not generated\n", name, propertyName, ref)
+				continue
+			}
+			if err := validateRelationName(spec.Name, field.Name, spec.Fields); err != nil {
+				fmt.Printf("Skipping property %s.%s: %v\n", name, propertyName, err)
+				continue
+			}
+			field.Name += "Id"
+			field.Type = ModelOptions{Key: document.keyType(ref)}.key().GoType
+			field.Relation = columnFieldName(ref)
+			field.Rules.Required = required[propertyName]
+			validateOpenAPIField(name, &field)
+			spec.Fields = append(spec.Fields, field)
+			continue
+		}
+
+		goType, nullable := openAPIGoType(property)
+		if goType == "" {
+			fmt.Printf("Skipping property %s.%s: arrays and nested objects are not generated\n", name, propertyName)
+			continue
+		}
+
+		// Properties the generated code cannot name a field after keep their JSON key under the suggested name.
+		// The timestamps left come without their pair, so the model does not declare them.
+		timestamp := toColumnName(field.Name) == "created_at" || toColumnName(field.Name) == "updated_at"
+		if err := validateFieldName(spec.Name, field.Name, spec.Fields); err != nil && !timestamp {
+			renamed := spec.Name + field.Name
+			if validateFieldName(spec.Name, renamed, spec.Fields) != nil {
+				fmt.Printf("Skipping property %s.%s: %v\n", name, propertyName, err)
+				continue
+			}
+			fmt.Printf("Naming the field of property %s.%s %s: %v\n", name, propertyName, renamed, err)
+			field.Name = renamed
+		}
+		if field.JSONName() != propertyName {
+			field.JSON = propertyName
+		}
+		field.Type = goType
+		field.Nullable = nullable || !required[propertyName]
+		if property.Default != nil {
+			field.Default = fmt.Sprint(property.Default)
+		}
+		if property.Example != nil {
+			field.Example = fmt.Sprint(property.Example)
+		}
+		field.Description = singleLine(property.Description)
+
+		// Read-only properties are only required in the responses
+		switch {
+		case property.ReadOnly:
+			field.Access = "readonly"
+		case property.WriteOnly:
+			field.Access = "writeonly"
+		}
+		field.Rules = FieldRules{
+			Required:  required[propertyName] && !property.ReadOnly,
+			MinLength: property.MinLength,
+			MaxLength: property.MaxLength,
+			Minimum:   property.Minimum,
+			Maximum:   property.Maximum,
+			Pattern:   property.Pattern,
+		}
+		if goType == "string" {
+			for _, option := range property.Enum {
+				field.Rules.Enum = append(field.Rules.Enum, fmt.Sprint(option))
+			}
+			switch property.Format {
+			case "email", "uri", "url":
+				field.Rules.Format = property.Format
+			}
+		}
+		validateOpenAPIField(name, &field)
+		spec.Fields = append(spec.Fields, field)
+	}
+	return spec, options
+}
+
+// validateOpenAPIField runs an imported field through the checks of the prompts, dropping the default,
+// example, description, access or required check the generated code cannot use.
+func validateOpenAPIField(schemaName string, field *ModelField) {
+	if err := validateDefault(field); err != nil {
+		fmt.Printf("Skipping the default of %s.%s: %v\n", schemaName, field.Name, err)
+		field.Default = ""
+	}
+	if err := validateDescription(*field); err != nil {
+		fmt.Printf("Skipping the description of %s.%s: %v\n", schemaName, field.Name, err)
+		field.Description = ""
+	}
+	if err := validateExample(field); err != nil {
+		fmt.Printf("Skipping the example of %s.%s: %v\n", schemaName, field.Name, err)
+		field.Example = ""
+	}
+	if err := validateAccess(*field); err != nil {
+		fmt.Printf("Skipping the access of %s.%s: %v\n", schemaName, field.Name, err)
+		field.Access = ""
+	}
+	if err := validateRequired(*field); err != nil {
+		fmt.Printf("Skipping the required check of %s.%s: %v\n", schemaName, field.Name, err)
+		field.Rules.Required = false
+	}
+}
+
+// keyType returns the key type of a components schema from its id property: integers are uint, or int64
+// with the int64 format, and strings uuid with the uuid format, or else ulid. Schemas without an id
+// use the key type of the project.
+func (document *openAPIDocument) keyType(name string) string {
+	schema := document.Components.Schemas[name]
+	if schema == nil {
+		return ""
+	}
+	properties, _ := document.properties(schema)
+	for _, propertyName := range []string{"id", "ID", "Id"} {
+		property, ok := properties[propertyName]
+		if !ok {
+			continue
+		}
+		switch goType, _ := openAPIGoType(property); goType {
+		case "int", "int32":
+			return "uint"
+		case "int64":
+			return "int64"
+		case "uuid.UUID":
+			return "uuid"
+		case "string":
+			return "ulid"
+		}
+	}
+	return ""
+}
+
+// openAPIGoType maps a property schema to a Go type, reporting whether the schema allows null.
+// Arrays and objects have no column type and return an empty type.
+func openAPIGoType(schema *openAPISchema) (string, bool) {
+	nullable := schema.Nullable
+	schemaType := ""
+	for _, candidate := range schema.types() {
+		if candidate == "null" {
+			nullable = true
+		} else if schemaType == "" {
+			schemaType = candidate
+		}
+	}
+
+	switch schemaType {
+	case "integer":
+		switch schema.Format {
+		case "int32":
+			return "int32", nullable
+		case "int64":
+			return "int64", nullable
+		}
+		return "int", nullable
+	case "number":
+		if schema.Format == "float" {
+			return "float32", nullable
+		}
+		return "float64", nullable
+	case "boolean":
+		return "bool", nullable
+	case "string":
+		switch schema.Format {
+		case "date", "date-time":
+			return "time.Time", nullable
+		case "byte", "binary":
+			return "[]byte", nullable
+		case "uuid":
+			return "uuid.UUID", nullable
+		}
+		return "string", nullable
+	}
+	return "", nullable
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+)
+
+// ModelOptions holds the per-model choices that change the generated layers.
+type ModelOptions struct {
+	// SoftDelete keeps deleted rows through gorm.DeletedAt and generates the
+	// Restore, FindTrashed and ForceDelete operations. When false, rows are hard deleted.
+	SoftDelete bool
+
+	// Routes overrides the CRUD routes of the handler. When nil every route is
+	// generated under the model name.
+	Routes *HandlerRoutes
+
+	// Key is the primary key type (uint, int64, uuid or ulid). When empty the
+	// key type of the project configuration is used, uint by default.
+	Key string
+}
+
+// DefaultModelOptions returns the options used when no flag overrides them.
+func DefaultModelOptions() ModelOptions {
+	return ModelOptions{SoftDelete: true}
+}
+
+// HandlerRoutes holds the CRUD routes of a handler. Operations left nil are not generated.
+type HandlerRoutes struct {
+	List   *HandlerOperation
+	Get    *HandlerOperation
+	Create *HandlerOperation
+	Update *HandlerOperation
+	Delete *HandlerOperation
+}
+
+// HandlerOperation is a single CRUD route of a handler.
+type HandlerOperation struct {
+	Method  string // HTTP method, only PUT or PATCH change the update route
+	Path    string // fiber path relative to the route prefix, like "/users/:id"
+	Status  int    // status code of a successful response
+	Summary string // Swagger summary, a generated one when empty
+	Tag     string // Swagger tag, the plural struct name when empty
+}
+
+// DefaultHandlerRoutes returns the routes generated for a model when none are given.
+func DefaultHandlerRoutes(modelName string) *HandlerRoutes {
+	collection := "/" + resourcePath(modelName)
+	item := collection + "/:id"
+	return &HandlerRoutes{
+		List:   &HandlerOperation{Method: http.MethodGet, Path: collection, Status: http.StatusOK},
+		Get:    &HandlerOperation{Method: http.MethodGet, Path: item, Status: http.StatusOK},
+		Create: &HandlerOperation{Method: http.MethodPost, Path: collection, Status: http.StatusCreated},
+		Update: &HandlerOperation{Method: http.MethodPut, Path: item, Status: http.StatusOK},
+		Delete: &HandlerOperation{Method: http.MethodDelete, Path: item, Status: http.StatusOK},
+	}
+}
+
+// resourcePath returns the path segment of the routes of a model, its plural in kebab-case:
+// "OrderItem" gives "order-items".
+func resourcePath(modelName string) string {
+	return inflection.Dasherize(inflection.Pluralize(modelName))
+}
+
+// handlerRoutes returns the routes of the options, or the default ones of the model.
+func (o ModelOptions) handlerRoutes(modelName string) *HandlerRoutes {
+	if o.Routes != nil {
+		return o.Routes
+	}
+	return DefaultHandlerRoutes(modelName)
+}
+
+// IDParam returns the name of the path parameter holding the ID, like "id" for "/users/:id".
+func (op *HandlerOperation) IDParam() string {
+	if index := strings.LastIndex(op.Path, "/:"); index >= 0 {
+		return op.Path[index+2:]
+	}
+	return "id"
+}
+
+// SwaggerPath returns the path in the Swagger @Router syntax, like "/users/{id}".
+func (op *HandlerOperation) SwaggerPath() string {
+	if index := strings.LastIndex(op.Path, "/:"); index >= 0 {
+		return op.Path[:index] + "/{" + op.Path[index+2:] + "}"
+	}
+	return op.Path
+}
+
+// TestPath returns the path requested by the handler tests with id in place of the parameter.
+func (op *HandlerOperation) TestPath(id string) string {
+	if index := strings.LastIndex(op.Path, "/:"); index >= 0 {
+		return op.Path[:index] + "/" + id
+	}
+	return op.Path
+}
+
+// fiberStatus returns the fiber constant of an HTTP status code, or the code itself.
+func fiberStatus(status int) string {
+	switch status {
+	case http.StatusOK:
+		return "fiber.StatusOK"
+	case http.StatusCreated:
+		return "fiber.StatusCreated"
+	case http.StatusAccepted:
+		return "fiber.StatusAccepted"
+	case http.StatusNoContent:
+		return "fiber.StatusNoContent"
+	}
+	return fmt.Sprint(status)
+}
+
+// fiberMethod returns the fiber.App method registering a route for an HTTP method.
+func fiberMethod(method string) string {
+	method = strings.ToUpper(method)
+	return method[:1] + strings.ToLower(method[1:])
+}
@@ -0,0 +1,470 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// modelRenamer rewrites the spellings of a model name, like "OrderItem", "orderItem", "order_item"
+// and "order-item" and their plurals, into the spellings of a new name.
+type modelRenamer struct {
+	spellings [][2]string // old and new spelling, longest first
+	kept      []string    // names containing an old spelling that are left as they are
+}
+
+func newModelRenamer(oldName, newName string) *modelRenamer {
+	renamer := &modelRenamer{}
+	seen := map[string]bool{}
+	for _, inflect := range []func(string) string{func(name string) string { return name }, inflection.Pluralize} {
+		for _, spell := range []func(string) string{inflection.Pascalize, inflection.Camelize, inflection.Underscore, inflection.Dasherize} {
+			if old := spell(inflect(oldName)); !seen[old] {
+				seen[old] = true
+				renamer.spellings = append(renamer.spellings, [2]string{old, spell(inflect(newName))})
+			}
+		}
+	}
+	sort.SliceStable(renamer.spellings, func(i, j int) bool {
+		return len(renamer.spellings[i][0]) > len(renamer.spellings[j][0])
+	})
+	return renamer
+}
+
+// keep leaves the names containing an old spelling untouched, like other models named "CustomerAddress"
+// or fields named "CustomerId" when renaming "Customer". Spellings of the model name itself are not kept.
+func (r *modelRenamer) keep(names ...string) {
+	for _, name := range names {
+		if r.isSpelling(name) {
+			continue
+		}
+		for _, spelling := range r.spellings {
+			if strings.Contains(name, spelling[0]) {
+				r.kept = append(r.kept, name)
+				break
+			}
+		}
+	}
+	sort.SliceStable(r.kept, func(i, j int) bool { return len(r.kept[i]) > len(r.kept[j]) })
+}
+
+func (r *modelRenamer) isSpelling(name string) bool {
+	for _, spelling := range r.spellings {
+		if name == spelling[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// replace rewrites the old spellings found in text. A spelling starts a word, or follows a lowercase
+// letter when it is capitalized, and ends the word.
+func (r *modelRenamer) replace(text string) string {
+	var result strings.Builder
+	for i := 0; i < len(text); {
+		if kept := r.keptAt(text, i); kept != "" {
+			result.WriteString(kept)
+			i += len(kept)
+			continue
+		}
+		if old, new := r.spellingAt(text, i); old != "" {
+			result.WriteString(new)
+			i += len(old)
+			continue
+		}
+		result.WriteByte(text[i])
+		i++
+	}
+	return result.String()
+}
+
+func (r *modelRenamer) keptAt(text string, i int) string {
+	for _, kept := range r.kept {
+		if strings.HasPrefix(text[i:], kept) {
+			return kept
+		}
+	}
+	return ""
+}
+
+func (r *modelRenamer) spellingAt(text string, i int) (string, string) {
+	for _, spelling := range r.spellings {
+		old := spelling[0]
+		if !strings.HasPrefix(text[i:], old) {
+			continue
+		}
+		if i > 0 && isWordByte(text[i-1]) && (!isUpperByte(old[0]) || isUpperByte(text[i-1])) {
+			continue
+		}
+		if next := i + len(old); next < len(text) && isLowerByte(text[next]) {
+			continue
+		}
+		return old, spelling[1]
+	}
+	return "", ""
+}
+
+func isWordByte(b byte) bool {
+	return isUpperByte(b) || isLowerByte(b) || b >= '0' && b <= '9'
+}
+
+func isUpperByte(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
+
+func isLowerByte(b byte) bool {
+	return b >= 'a' && b <= 'z'
+}
+
+// RenameModel renames a model and every layer generated for it: files, packages, types, constructors,
+// routes, Swagger tags, the table name and the wiring. Other models keep the names of their
+// relationship fields and foreign keys, only the type they point at is renamed.
+func RenameModel(oldName, newName string, migrate bool) error {
+	structName := utils.ToPascalCase(oldName)
+	newStructName := utils.ToPascalCase(newName)
+	if err := validateModelName(newName); err != nil {
+		return err
+	}
+
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+	if _, err := FindModel(models, structName); err != nil {
+		return err
+	}
+	if _, err := FindModel(models, newStructName); err == nil {
+		return fmt.Errorf("model %s already exists", newStructName)
+	}
+
+	modelFilePath, err := modelFile(structName)
+	if err != nil {
+		return err
+	}
+	previousSchema, err := ReadModelSchema(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("error getting current directory: %v", err)
+	}
+	currentFolderName := filepath.Base(currentDir)
+
+	renamer := newModelRenamer(structName, newStructName)
+	renamer.keep(currentFolderName)
+	for _, model := range models {
+		renamer.keep(model.Name, utils.ToCamelCase(model.Name), utils.ToSnakeCase(model.Name), utils.ToUrlCase(model.Name))
+		for _, field := range model.Fields {
+			for _, name := range field.Names {
+				renamer.keep(name.Name, toColumnName(name.Name))
+			}
+		}
+	}
+
+	// The files generated for the model, the ones of its repository and service packages included
+	wiredName := wiredModelName(structName)
+	newWiredName := renamer.replace(wiredName)
+	files := []string{modelFilePath}
+	for _, path := range modelLayerPaths(structName, wiredName) {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		packageFiles, _ := filepath.Glob(filepath.Join(path, "*.go"))
+		files = append(files, packageFiles...)
+	}
+	for _, path := range files {
+		if newPath := renamer.replace(path); newPath != path {
+			if _, err := os.Stat(newPath); err == nil {
+				return fmt.Errorf("%s already exists", newPath)
+			}
+		}
+	}
+
+	// References from the rest of the project to what the model files declare
+	names := map[string]string{
+		wiredName + "Repository": newWiredName + "Repository",
+		wiredName + "Service":    newWiredName + "Service",
+	}
+	for _, path := range files {
+		for _, name := range topLevelNames(path) {
+			if newName := renamer.replace(name); newName != name {
+				names[name] = newName
+			}
+		}
+	}
+	packagePaths := map[string]string{}
+	for _, layer := range []string{"repository", "service"} {
+		packagePath := fmt.Sprintf("%s/internal/app/domain/%s/%s", currentFolderName, layer, wiredName)
+		packagePaths[packagePath] = renamer.replace(packagePath)
+	}
+
+	// A one-word name has the same camelCase and snake_case spellings, the table name follows snake_case
+	var tableRename [2]string
+	for _, table := range previousSchema.Tables {
+		if table.Model == structName && table.Name == modelTableName(ModelSpec{Name: structName}) {
+			tableRename = [2]string{strconv.Quote(renamer.replace(table.Name)), strconv.Quote(modelTableName(ModelSpec{Name: newStructName}))}
+		}
+	}
+
+	// Variables named after a model get a suffix when the generated code uses their name, like inputRecord,
+	// so they are renamed apart from the packages and files spelled alike
+	variables := map[string]string{}
+	for _, name := range []string{structName, inflection.Pluralize(structName)} {
+		oldVariable, newVariable := modelVariable(name), modelVariable(renamer.replace(name))
+		if renamer.replace(oldVariable) != newVariable {
+			variables[oldVariable] = newVariable
+			renamer.keep(newVariable)
+		}
+	}
+
+	fmt.Println("Files renamed:")
+	for _, path := range files {
+		newPath := renamer.replace(path)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(newPath), os.ModePerm); err != nil {
+			return fmt.Errorf("error creating directory %s: %v", filepath.Dir(newPath), err)
+		}
+		renamed := renamer.replaceSource(string(content), variables)
+		if path == modelFilePath && tableRename[0] != "" {
+			renamed = strings.Replace(renamed, "return "+tableRename[0], "return "+tableRename[1], 1)
+		}
+		if err := writeRenamed(newPath, content, renamed); err != nil {
+			return err
+		}
+		if newPath == path {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("error removing %s: %v", path, err)
+		}
+		fmt.Printf("- %s -> %s\n", path, newPath)
+	}
+	for _, layer := range []string{"repository", "service"} {
+		// Left in place when it still holds other files than Go sources
+		os.Remove(filepath.Join("internal", "app", "domain", layer, wiredName))
+	}
+
+	// The wiring only holds generated lines, other files only get their references renamed
+	wiringFiles := []string{
+		filepath.Join("internal", "app", "domain", "services.go"),
+		filepath.Join("internal", "app", "adapter", "handlers.go"),
+	}
+	for _, path := range wiringFiles {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if err := writeRenamed(path, content, renamer.replaceSource(string(content), variables)); err != nil {
+			return err
+		}
+	}
+	renamedFiles := map[string]bool{seedRunnerPath: true}
+	for _, path := range append(files, wiringFiles...) {
+		renamedFiles[renamer.replace(path)] = true
+	}
+	for _, dir := range []string{"internal", "cmd"} {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || !strings.HasSuffix(path, ".go") || renamedFiles[path] {
+				return err
+			}
+			return renameReferences(path, names, packagePaths)
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error renaming references in %s: %v", dir, err)
+		}
+	}
+
+	if _, err := os.Stat(seedRunnerPath); err == nil {
+		if err := writeSeedRunner(); err != nil {
+			return err
+		}
+	}
+
+	if !migrate {
+		return nil
+	}
+	currentSchema, err := ReadModelSchema(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+	renames := schemaRenames{Tables: map[string]string{}}
+	for _, table := range previousSchema.Tables {
+		for _, current := range currentSchema.Tables {
+			if table.Model == structName && current.Model == newStructName && table.Name != current.Name {
+				renames.Tables[table.Name] = current.Name
+			}
+		}
+	}
+	return generateMigration(fmt.Sprintf("rename_%s_to_%s", utils.ToSnakeCase(structName), utils.ToSnakeCase(newStructName)), renames)
+}
+
+// topLevelNames returns the names of the functions, types, variables and constants declared by a Go file.
+func topLevelNames(path string) []string {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				names = append(names, decl.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, spec.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// renameReferences renames the identifiers of names used by a Go file, like model.Customer or
+// NewCustomerFactory, and the import paths of packagePaths. Field names and the fields selected
+// on values are left untouched.
+func renameReferences(path string, names, packagePaths map[string]string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", path, err)
+	}
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, path, content, 0)
+	if err != nil {
+		return nil
+	}
+
+	imported := map[string]bool{}
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			imported[spec.Name.Name] = true
+		} else {
+			imported[importPath[strings.LastIndex(importPath, "/")+1:]] = true
+		}
+	}
+
+	type edit struct {
+		offset int
+		old    string
+		new    string
+	}
+	var edits []edit
+	skipped := map[*ast.Ident]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.Field:
+			for _, name := range node.Names {
+				skipped[name] = true
+			}
+		case *ast.KeyValueExpr:
+			if key, ok := node.Key.(*ast.Ident); ok {
+				skipped[key] = true
+			}
+		case *ast.SelectorExpr:
+			if x, ok := node.X.(*ast.Ident); !ok || !imported[x.Name] {
+				skipped[node.Sel] = true
+			}
+		case *ast.ImportSpec:
+			importPath, _ := strconv.Unquote(node.Path.Value)
+			if newPath, ok := packagePaths[importPath]; ok {
+				edits = append(edits, edit{fileSet.Position(node.Path.Pos()).Offset, node.Path.Value, strconv.Quote(newPath)})
+			}
+		case *ast.Ident:
+			if newName, ok := names[node.Name]; ok && !skipped[node] {
+				edits = append(edits, edit{fileSet.Position(node.Pos()).Offset, node.Name, newName})
+			}
+		}
+		return true
+	})
+	if len(edits) == 0 {
+		return nil
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].offset > edits[j].offset })
+	updated := string(content)
+	for _, edit := range edits {
+		updated = updated[:edit.offset] + edit.new + updated[edit.offset+len(edit.old):]
+	}
+	return writeRenamed(path, content, updated)
+}
+
+// replaceSource rewrites the old spellings found in a Go source like replace, except in the identifiers of the
+// generated code spelled like the model, like the input variables of the handlers of a model named Input.
+// The variables holding the model are renamed from variables. Sources that do not parse are replaced as text.
+func (r *modelRenamer) replaceSource(content string, variables map[string]string) string {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", content, 0)
+	if err != nil {
+		return r.replace(content)
+	}
+
+	var idents []*ast.Ident
+	ast.Inspect(file, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Ident); ok {
+			if variables[ident.Name] != "" && ident.Obj != nil && ident.Obj.Kind == ast.Var ||
+				generatedIdentifiers[ident.Name] && r.isSpelling(ident.Name) {
+				idents = append(idents, ident)
+			}
+		}
+		return true
+	})
+
+	sort.Slice(idents, func(i, j int) bool { return idents[i].Pos() < idents[j].Pos() })
+	var result strings.Builder
+	last := 0
+	for _, ident := range idents {
+		offset := fileSet.Position(ident.Pos()).Offset
+		result.WriteString(r.replace(content[last:offset]))
+		if newName := variables[ident.Name]; newName != "" {
+			result.WriteString(newName)
+		} else {
+			result.WriteString(ident.Name)
+		}
+		last = offset + len(ident.Name)
+	}
+	result.WriteString(r.replace(content[last:]))
+	return result.String()
+}
+
+// writeRenamed writes the renamed version of a Go file, formatted again when the original was gofmt'd,
+// since the longer or shorter names break the alignment of its columns.
+func writeRenamed(path string, original []byte, updated string) error {
+	if formatted, err := format.Source(original); err == nil && bytes.Equal(formatted, original) {
+		if formatted, err := format.Source([]byte(updated)); err == nil {
+			updated = string(formatted)
+		}
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package commands
+
+import "testing"
+
+func TestModelRenamerReplaceSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldName   string
+		newName   string
+		variables map[string]string
+		source    string
+		want      string
+	}{
+		{
+			name:    "model variables",
+			oldName: "Article",
+			newName: "Post",
+			source: `package handler
+
+func (h *ArticleHandler) createArticle(c *fiber.Ctx) error {
+	article := mapper.ArticleMapToModel(*input)
+	return h.services.ArticleService.Create(c.UserContext(), &article)
+}
+`,
+			want: `package handler
+
+func (h *PostHandler) createPost(c *fiber.Ctx) error {
+	post := mapper.PostMapToModel(*input)
+	return h.services.PostService.Create(c.UserContext(), &post)
+}
+`,
+		},
+		{
+			name:      "to a name the generated code uses",
+			oldName:   "Article",
+			newName:   "Input",
+			variables: map[string]string{"article": "inputRecord"},
+			source: `package handler
+
+// createArticle creates an article
+func (h *ArticleHandler) createArticle(c *fiber.Ctx) error {
+	input := new(inbound.Article)
+	article := mapper.ArticleMapToModel(*input)
+	return h.services.ArticleService.Create(c.UserContext(), &article)
+}
+`,
+			want: `package handler
+
+// createInput creates an input
+func (h *InputHandler) createInput(c *fiber.Ctx) error {
+	input := new(inbound.Input)
+	inputRecord := mapper.InputMapToModel(*input)
+	return h.services.InputService.Create(c.UserContext(), &inputRecord)
+}
+`,
+		},
+		{
+			name:      "from a name the generated code uses",
+			oldName:   "Input",
+			newName:   "Article",
+			variables: map[string]string{"inputRecord": "article"},
+			source: `package handler
+
+import inputRepository "project/internal/app/domain/repository/input"
+
+func (h *InputHandler) createInput(c *fiber.Ctx) error {
+	input := new(inbound.Input)
+	inputRecord := mapper.InputMapToModel(*input)
+	return h.services.InputService.Create(c.UserContext(), &inputRecord)
+}
+`,
+			want: `package handler
+
+import articleRepository "project/internal/app/domain/repository/article"
+
+func (h *ArticleHandler) createArticle(c *fiber.Ctx) error {
+	input := new(inbound.Article)
+	article := mapper.ArticleMapToModel(*input)
+	return h.services.ArticleService.Create(c.UserContext(), &article)
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renamer := newModelRenamer(tt.oldName, tt.newName)
+			if got := renamer.replaceSource(tt.source, tt.variables); got != tt.want {
+				t.Errorf("replaceSource() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
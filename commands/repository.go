@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
 )
 
 // GenerateRepository generates Go repository files for a given model name.
-func GenerateRepository(modelName, structName string) error {
+func GenerateRepository(modelName string, spec ModelSpec, options ModelOptions) error {
+	structName := spec.Name
+
 	// Get the current working directory
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -29,26 +35,29 @@ func GenerateRepository(modelName, structName string) error {
 
 	// Generate the Repository interface file
 	repositoryFilePath := filepath.Join(repositoryDir, fmt.Sprintf("%sRepository.go", modelName))
-	if err := writeRepositoryInterfaceFile(repositoryFilePath, currentFolderName, modelName, structName); err != nil {
+	if err := writeRepositoryInterfaceFile(repositoryFilePath, currentFolderName, modelName, structName, options); err != nil {
 		return fmt.Errorf("error writing repository interface file: %v", err)
 	}
 
 	// Generate the Repository implementation file
 	repositoryImplFilePath := filepath.Join(repositoryDir, fmt.Sprintf("%sRepositoryImpl.go", modelName))
-	if err := writeRepositoryImplFile(repositoryImplFilePath, currentFolderName, modelName, structName); err != nil {
+	if err := writeRepositoryImplFile(repositoryImplFilePath, currentFolderName, modelName, spec, options); err != nil {
 		return fmt.Errorf("error writing repository implementation file: %v", err)
 	}
 
-	fmt.Printf("Repository files generated in: %s\n", repositoryDir)
-
-	if err := addModelToMigrations(structName, currentFolderName); err != nil {
-		return fmt.Errorf("error writing migrations file: %v", err)
+	// Generate the Repository integration test file
+	repositoryTestFilePath := filepath.Join(repositoryDir, fmt.Sprintf("%sRepositoryImpl_test.go", modelName))
+	if err := writeRepositoryTestFile(repositoryTestFilePath, currentFolderName, modelName, spec, options); err != nil {
+		return fmt.Errorf("error writing repository test file: %v", err)
 	}
+
+	fmt.Printf("Repository files generated in: %s\n", repositoryDir)
+	fmt.Println("Repository tests use an in-memory SQLite database, run `go get gorm.io/driver/sqlite` if it is not yet a dependency.")
 	return nil
 }
 
 // writeRepositoryInterfaceFile creates the repository interface file.
-func writeRepositoryInterfaceFile(filePath, currentFolderName, modelName, structName string) error {
+func writeRepositoryInterfaceFile(filePath, currentFolderName, modelName, structName string, options ModelOptions) error {
 	file, err := os.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("error creating file: %v", err)
@@ -56,20 +65,34 @@ func writeRepositoryInterfaceFile(filePath, currentFolderName, modelName, struct
 	defer file.Close()
 
 	writer := bufio.NewWriter(file)
+	key := options.key()
+
+	// Soft-deleted models can also be restored, listed from the trash and purged
+	softDeleteMethods := ""
+	if options.SoftDelete {
+		softDeleteMethods = fmt.Sprintf(`	Restore(ctx context.Context, id %[2]s) error
+	FindTrashed(ctx context.Context) ([]*model.%[1]s, error)
+	ForceDelete(ctx context.Context, id %[2]s) error
+`, structName, key.GoType)
+	}
 
 	// Write the content
-	content := fmt.Sprintf(`package %sRepository
+	content := fmt.Sprintf(`package %[1]sRepository
 
-import "%s/internal/app/domain/model"
+import (
+	"context"
 
-type %sRepository interface {
-	Create(%s *model.%s) error
-	Update(id uint, %s *model.%s) error
-	Delete(id uint) error
-	FindAll() ([]*model.%s, error)
-	FindById(id uint) (*model.%s, error)
-}
-`, modelName, currentFolderName, structName, modelName, structName, modelName, structName, structName, structName)
+	"%[2]s/internal/app/domain/model"
+%[6]s)
+
+type %[3]sRepository interface {
+	Create(ctx context.Context, %[7]s *model.%[3]s) error
+	Update(ctx context.Context, id %[5]s, %[7]s *model.%[3]s) error
+	Delete(ctx context.Context, id %[5]s) error
+	FindAll(ctx context.Context) ([]*model.%[3]s, error)
+	FindById(ctx context.Context, id %[5]s) (*model.%[3]s, error)
+%[4]s}
+`, modelName, currentFolderName, structName, softDeleteMethods, key.GoType, key.ImportLine(key.GoType), modelVariable(modelName))
 
 	writer.WriteString(content)
 	writer.Flush()
@@ -77,7 +100,10 @@ type %sRepository interface {
 }
 
 // writeRepositoryImplFile creates the repository implementation file.
-func writeRepositoryImplFile(filePath, currentFolderName, modelName, structName string) error {
+// Every query is bound to the caller's context through WithContext so that
+// cancellation and deadlines reach GORM. Soft deletion relies on the
+// gorm.DeletedAt scopes, so deleted rows never leak into the default queries.
+func writeRepositoryImplFile(filePath, currentFolderName, modelName string, spec ModelSpec, options ModelOptions) error {
 	file, err := os.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("error creating file: %v", err)
@@ -85,198 +111,446 @@ func writeRepositoryImplFile(filePath, currentFolderName, modelName, structName
 	defer file.Close()
 
 	writer := bufio.NewWriter(file)
+	structName := spec.Name
+	key := options.key()
+
+	softDeleteMethods := ""
+	if options.SoftDelete {
+		softDeleteMethods = fmt.Sprintf(`
+func (r *%[2]sRepositoryImpl) Restore(ctx context.Context, id %[4]s) error {
+	result := r.db.Write.WithContext(ctx).Unscoped().
+		Model(&model.%[2]s{}).
+		Where("%[6]s = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *%[2]sRepositoryImpl) FindTrashed(ctx context.Context) ([]*model.%[2]s, error) {
+	var %[3]s []*model.%[2]s
+	err := r.db.Read.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Find(&%[3]s).Error
+	return %[3]s, err
+}
+
+func (r *%[2]sRepositoryImpl) ForceDelete(ctx context.Context, id %[4]s) error {
+	%[1]s := &model.%[2]s{}
+	if err := r.db.Write.WithContext(ctx).Unscoped().First(%[1]s, %[5]s).Error; err != nil {
+		return err
+	}
+	return r.db.Write.WithContext(ctx).Unscoped().Delete(%[1]s).Error
+}
+`, modelVariable(modelName), structName, modelVariable(inflection.Pluralize(structName)), key.GoType, key.lookup(spec.idColumn()), spec.idColumn())
+	}
 
 	// Write the content
-	content := fmt.Sprintf(`package %sRepository
+	content := fmt.Sprintf(`package %[9]sRepository
 
 import (
-	"%s/internal/app/domain/model"
-	"%s/internal/infra/database"
-	"time"
+	"context"
+
+	"%[2]s/internal/app/domain/model"
+	"%[2]s/internal/infra/database"
+%[7]s	"gorm.io/gorm"
 )
 
-var _ %sRepository = (*%sRepositoryImpl)(nil)
+var _ %[3]sRepository = (*%[3]sRepositoryImpl)(nil)
 
-type %sRepositoryImpl struct {
+type %[3]sRepositoryImpl struct {
 	db *database.Databases
 }
 
-func New%sRepository(db *database.Databases) *%sRepositoryImpl {
-	return &%sRepositoryImpl{db: db}
+func New%[3]sRepository(db *database.Databases) *%[3]sRepositoryImpl {
+	return &%[3]sRepositoryImpl{db: db}
 }
 
-func (r *%sRepositoryImpl) Create(%s *model.%s) error {
-	return r.db.Write.Create(%s).Error
+func (r *%[3]sRepositoryImpl) Create(ctx context.Context, %[1]s *model.%[3]s) error {
+	return r.translateError(r.db.Write.WithContext(ctx).Create(%[1]s).Error)
 }
 
-func (r *%sRepositoryImpl) Update(id uint, %s *model.%s) error {
-	existing := &model.%s{}
-	if err := r.db.Write.First(existing, id).Error; err != nil {
+func (r *%[3]sRepositoryImpl) Update(ctx context.Context, id %[6]s, %[1]s *model.%[3]s) error {
+	existing := &model.%[3]s{}
+	if err := r.db.Write.WithContext(ctx).First(existing, %[8]s).Error; err != nil {
 		return err
 	}
-	return r.db.Write.Model(existing).Updates(%s).Error
+	return r.translateError(r.db.Write.WithContext(ctx).Model(existing).Updates(%[1]s).Error)
 }
 
-func (r *%sRepositoryImpl) Delete(id uint) error {
-	%s := &model.%s{}
-	if err := r.db.Write.First(%s, id).Error; err != nil {
+func (r *%[3]sRepositoryImpl) Delete(ctx context.Context, id %[6]s) error {
+	%[1]s := &model.%[3]s{}
+	if err := r.db.Write.WithContext(ctx).First(%[1]s, %[8]s).Error; err != nil {
 		return err
 	}
-	return r.db.Write.Model(%s).Update("deleted_at", time.Now()).Error
+	return r.db.Write.WithContext(ctx).Delete(%[1]s).Error
 }
 
-func (r *%sRepositoryImpl) FindAll() ([]*model.%s, error) {
-	var %ss []*model.%s
-	err := r.db.Read.
-		Where("deleted_at IS NULL").
-		Find(&%ss).Error
-	return %ss, err
+func (r *%[3]sRepositoryImpl) FindAll(ctx context.Context) ([]*model.%[3]s, error) {
+	var %[5]s []*model.%[3]s
+	err := r.db.Read.WithContext(ctx).Find(&%[5]s).Error
+	return %[5]s, err
 }
 
-func (r *%sRepositoryImpl) FindById(id uint) (*model.%s, error) {
-	var %s model.%s
-	err := r.db.Read.
-		Where("id = ? AND deleted_at IS NULL", id).
-		First(&%s).Error
-	return &%s, err
+func (r *%[3]sRepositoryImpl) FindById(ctx context.Context, id %[6]s) (*model.%[3]s, error) {
+	var %[1]s model.%[3]s
+	err := r.db.Read.WithContext(ctx).First(&%[1]s, %[8]s).Error
+	return &%[1]s, err
 }
-`,
-		modelName,
-		currentFolderName,
-		currentFolderName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		structName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		modelName,
-		structName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		modelName,
-		structName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		modelName)
+%[4]s
+// translateError turns the unique violations reported by the database into gorm.ErrDuplicatedKey,
+// which the handler answers with a conflict.
+func (r *%[3]sRepositoryImpl) translateError(err error) error {
+	if translator, ok := r.db.Write.Dialector.(gorm.ErrorTranslator); ok && err != nil {
+		return translator.Translate(err)
+	}
+	return err
+}
+`, modelVariable(modelName), currentFolderName, structName, softDeleteMethods, modelVariable(inflection.Pluralize(structName)),
+		key.GoType, key.ImportLine(key.GoType), key.lookup(spec.idColumn()), modelName)
 
 	writer.WriteString(content)
 	writer.Flush()
 	return nil
 }
 
-func addModelToMigrations(structName, currentFolderName string) error {
-	// Path to the databases.go file
-	databasesFilePath := filepath.Join("internal", "infra", "database", "databases.go")
+// writeRepositoryTestFile creates the integration tests for the repository implementation.
+// They run against an in-memory SQLite database shared by the Read and Write handles.
+func writeRepositoryTestFile(filePath, currentFolderName, modelName string, spec ModelSpec, options ModelOptions) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	structName := spec.Name
+	key := options.key()
+	variable := modelVariable(modelName)
+
+	// Soft-deleted models are checked for the scope filter and the trash operations,
+	// hard-deleted ones for the removal of the row
+	deleteTests := fmt.Sprintf(`
+func Test%[3]sRepositoryHardDelete(t *testing.T) {
+	ctx := context.Background()
+	dbs := newTestDatabases(t)
+	repository := New%[3]sRepository(dbs)
+
+	%[1]s := new%[3]s(0)
+	if err := repository.Create(ctx, %[1]s); err != nil {
+		t.Fatalf("Create returned an error: %%v", err)
+	}
+
+	if err := repository.Delete(ctx, %[1]s.ID); err != nil {
+		t.Fatalf("Delete returned an error: %%v", err)
+	}
+	if count := count%[3]sRows(t, dbs, %[1]s.ID); count != 0 {
+		t.Errorf("expected the record to be removed, found %%d rows", count)
+	}
+}
+`, variable, currentFolderName, structName)
+	if options.SoftDelete {
+		deleteTests = fmt.Sprintf(`
+func Test%[3]sRepositorySoftDeleteFilter(t *testing.T) {
+	ctx := context.Background()
+	dbs := newTestDatabases(t)
+	repository := New%[3]sRepository(dbs)
+
+	kept := new%[3]s(1)
+	deleted := new%[3]s(2)
+	for _, %[1]s := range []*model.%[3]s{kept, deleted} {
+		if err := repository.Create(ctx, %[1]s); err != nil {
+			t.Fatalf("Create returned an error: %%v", err)
+		}
+	}
+
+	if err := repository.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete returned an error: %%v", err)
+	}
 
-	// Read the existing content of the file
-	content, err := os.ReadFile(databasesFilePath)
+	%[4]s, err := repository.FindAll(ctx)
 	if err != nil {
-		return fmt.Errorf("error reading databases.go: %v", err)
+		t.Fatalf("FindAll returned an error: %%v", err)
+	}
+	if len(%[4]s) != 1 || %[4]s[0].ID != kept.ID {
+		t.Errorf("expected only record %%v, got %%v", kept.ID, %[4]s)
 	}
 
-	// Convert to string for modification
-	contentStr := string(content)
+	if _, err := repository.FindById(ctx, deleted.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected gorm.ErrRecordNotFound for a deleted record, got %%v", err)
+	}
 
-	// Check if the model is already added to migrations
-	if containsModel(contentStr, structName) {
-		fmt.Printf("Model %s already exists in migrations.\n", structName)
-		return nil
+	// The row must still be stored, only hidden from the repository queries
+	if count := count%[3]sRows(t, dbs, deleted.ID); count != 1 {
+		t.Errorf("expected the deleted record to be kept, found %%d rows", count)
 	}
+}
+
+func Test%[3]sRepositoryRestore(t *testing.T) {
+	ctx := context.Background()
+	repository := New%[3]sRepository(newTestDatabases(t))
 
-	// Construct the model import path dynamically
-	modelImport := fmt.Sprintf(`"%s/internal/app/domain/model"`, currentFolderName)
-	if !containsImport(contentStr, modelImport) {
-		contentStr = addImport(contentStr, modelImport)
+	%[1]s := new%[3]s(0)
+	if err := repository.Create(ctx, %[1]s); err != nil {
+		t.Fatalf("Create returned an error: %%v", err)
 	}
 
-	// Add the model to the runMigrations function
-	migrationEntry := fmt.Sprintf("&model.%s{},", structName)
-	contentStr = addMigrationEntry(contentStr, migrationEntry)
+	if err := repository.Restore(ctx, %[1]s.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected gorm.ErrRecordNotFound when restoring a live record, got %%v", err)
+	}
 
-	// Write the updated content back to the file
-	err = os.WriteFile(databasesFilePath, []byte(contentStr), os.ModePerm)
+	if err := repository.Delete(ctx, %[1]s.ID); err != nil {
+		t.Fatalf("Delete returned an error: %%v", err)
+	}
+	if err := repository.Restore(ctx, %[1]s.ID); err != nil {
+		t.Fatalf("Restore returned an error: %%v", err)
+	}
+	if _, err := repository.FindById(ctx, %[1]s.ID); err != nil {
+		t.Errorf("expected the restored record to be found, got %%v", err)
+	}
+}
+
+func Test%[3]sRepositoryFindTrashed(t *testing.T) {
+	ctx := context.Background()
+	repository := New%[3]sRepository(newTestDatabases(t))
+
+	kept := new%[3]s(1)
+	deleted := new%[3]s(2)
+	for _, %[1]s := range []*model.%[3]s{kept, deleted} {
+		if err := repository.Create(ctx, %[1]s); err != nil {
+			t.Fatalf("Create returned an error: %%v", err)
+		}
+	}
+	if err := repository.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("Delete returned an error: %%v", err)
+	}
+
+	trashed, err := repository.FindTrashed(ctx)
 	if err != nil {
-		return fmt.Errorf("error writing to databases.go: %v", err)
+		t.Fatalf("FindTrashed returned an error: %%v", err)
 	}
+	if len(trashed) != 1 || trashed[0].ID != deleted.ID {
+		t.Errorf("expected only record %%v, got %%v", deleted.ID, trashed)
+	}
+}
 
-	fmt.Printf("Model %s added to migrations.\n", structName)
-	return nil
+func Test%[3]sRepositoryForceDelete(t *testing.T) {
+	ctx := context.Background()
+	dbs := newTestDatabases(t)
+	repository := New%[3]sRepository(dbs)
+
+	%[1]s := new%[3]s(0)
+	if err := repository.Create(ctx, %[1]s); err != nil {
+		t.Fatalf("Create returned an error: %%v", err)
+	}
+	if err := repository.Delete(ctx, %[1]s.ID); err != nil {
+		t.Fatalf("Delete returned an error: %%v", err)
+	}
+
+	if err := repository.ForceDelete(ctx, %[1]s.ID); err != nil {
+		t.Fatalf("ForceDelete returned an error: %%v", err)
+	}
+	if count := count%[3]sRows(t, dbs, %[1]s.ID); count != 0 {
+		t.Errorf("expected the record to be purged, found %%d rows", count)
+	}
+	if err := repository.ForceDelete(ctx, %[1]s.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected gorm.ErrRecordNotFound for a purged record, got %%v", err)
+	}
+}
+`, variable, currentFolderName, structName, modelVariable(inflection.Pluralize(structName)))
+	}
+	deleteTests += fmt.Sprintf(`
+// count%[3]sRows counts the stored rows with the given ID, including soft-deleted ones.
+func count%[3]sRows(t *testing.T, dbs *database.Databases, id %[4]s) int64 {
+	t.Helper()
+
+	var count int64
+	if err := dbs.Read.Unscoped().Model(&model.%[3]s{}).Where("%[5]s = ?", id).Count(&count).Error; err != nil {
+		t.Fatalf("error counting %[3]s rows: %%v", err)
+	}
+	return count
+}
+`, modelName, currentFolderName, structName, key.GoType, spec.idColumn())
+
+	// Records numbered alike conflict when the model has unique fields
+	if _, conflicts := uniqueTestFields(spec.Fields); conflicts {
+		deleteTests += fmt.Sprintf(`
+func Test%[1]sRepositoryCreateConflict(t *testing.T) {
+	ctx := context.Background()
+	repository := New%[1]sRepository(newTestDatabases(t))
+
+	if err := repository.Create(ctx, new%[1]s(1)); err != nil {
+		t.Fatalf("Create returned an error: %%v", err)
+	}
+	if err := repository.Create(ctx, new%[1]s(1)); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Errorf("expected gorm.ErrDuplicatedKey for a duplicate record, got %%v", err)
+	}
+}
+`, structName)
+	}
+	deleteTests += "\n" + repositoryTestModel(spec)
+	std, external := repositoryTestImports(spec, key)
+
+	// Write the content
+	content := fmt.Sprintf(`package %[10]sRepository
+
+import (
+%[7]s
+	"%[2]s/internal/app/domain/model"
+	"%[2]s/internal/infra/database"
+
+%[9]s	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDatabases opens an in-memory SQLite database and migrates the %[3]s model.
+// A single connection is kept open so every query sees the same in-memory database.
+func newTestDatabases(t *testing.T) *database.Databases {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("error opening database: %%v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("error getting database handle: %%v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(&model.%[3]s{}); err != nil {
+		t.Fatalf("error migrating %[3]s: %%v", err)
+	}
+
+	return &database.Databases{Read: db, Write: db}
 }
 
-func containsModel(content, structName string) bool {
-	return filepath.Base(content) == structName
+func Test%[3]sRepositoryCreateAndFindById(t *testing.T) {
+	ctx := context.Background()
+	repository := New%[3]sRepository(newTestDatabases(t))
+
+	%[1]s := new%[3]s(0)
+	if err := repository.Create(ctx, %[1]s); err != nil {
+		t.Fatalf("Create returned an error: %%v", err)
+	}
+	if %[1]s.ID == %[6]s {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	found, err := repository.FindById(ctx, %[1]s.ID)
+	if err != nil {
+		t.Fatalf("FindById returned an error: %%v", err)
+	}
+	if found.ID != %[1]s.ID {
+		t.Errorf("expected ID %%v, got %%v", %[1]s.ID, found.ID)
+	}
 }
 
-func containsImport(content, importStr string) bool {
-	return filepath.Base(content) == importStr
+func Test%[3]sRepositoryFindByIdNotFound(t *testing.T) {
+	repository := New%[3]sRepository(newTestDatabases(t))
+
+	if _, err := repository.FindById(context.Background(), %[8]s); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected gorm.ErrRecordNotFound, got %%v", err)
+	}
 }
 
-func addImport(content, importStr string) string {
-	importSection := "import ("
-	index := strings.Index(content, importSection) // Use strings.Index to find the position of "import ("
-	if index < 0 {
-		return content // Return the content unchanged if "import (" is not found
+func Test%[3]sRepositoryFindAll(t *testing.T) {
+	ctx := context.Background()
+	repository := New%[3]sRepository(newTestDatabases(t))
+
+	for i := 0; i < 3; i++ {
+		if err := repository.Create(ctx, new%[3]s(i)); err != nil {
+			t.Fatalf("Create returned an error: %%v", err)
+		}
+	}
+
+	%[5]s, err := repository.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll returned an error: %%v", err)
+	}
+	if len(%[5]s) != 3 {
+		t.Errorf("expected 3 records, got %%d", len(%[5]s))
 	}
-	return content[:index+len(importSection)] + "\n\t" + importStr + content[index+len(importSection):]
 }
 
-func addMigrationEntry(content, entry string) string {
-	migrationFunc := "func (d *Databases) runMigrations(db *gorm.DB) {"
-	index := strings.Index(content, migrationFunc) // Locate the runMigrations function
-	if index < 0 {
-		return content // If runMigrations function not found, return content as is
+func Test%[3]sRepositoryUpdate(t *testing.T) {
+	ctx := context.Background()
+	repository := New%[3]sRepository(newTestDatabases(t))
+
+	%[1]s := new%[3]s(0)
+	if err := repository.Create(ctx, %[1]s); err != nil {
+		t.Fatalf("Create returned an error: %%v", err)
 	}
 
-	// Find the AutoMigrate call within the runMigrations function
-	autoMigrateIndex := strings.Index(content[index:], "db.AutoMigrate(")
-	if autoMigrateIndex < 0 {
-		return content // If AutoMigrate is not found, return content as is
+	if err := repository.Update(ctx, %[1]s.ID, &model.%[3]s{}); err != nil {
+		t.Errorf("Update returned an error: %%v", err)
 	}
+	if err := repository.Update(ctx, %[8]s, &model.%[3]s{}); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected gorm.ErrRecordNotFound for a missing record, got %%v", err)
+	}
+}
 
-	// Calculate the start of the db.AutoMigrate call
-	start := index + autoMigrateIndex + len("db.AutoMigrate(")
+func Test%[3]sRepositoryDelete(t *testing.T) {
+	ctx := context.Background()
+	repository := New%[3]sRepository(newTestDatabases(t))
 
-	// Find the closing parenthesis for the AutoMigrate call
-	end := strings.Index(content[start:], ")")
-	if end < 0 {
-		return content // If the closing parenthesis is not found, return content as is
+	%[1]s := new%[3]s(0)
+	if err := repository.Create(ctx, %[1]s); err != nil {
+		t.Fatalf("Create returned an error: %%v", err)
+	}
+
+	if err := repository.Delete(ctx, %[1]s.ID); err != nil {
+		t.Fatalf("Delete returned an error: %%v", err)
+	}
+	if err := repository.Delete(ctx, %[8]s); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected gorm.ErrRecordNotFound for a missing record, got %%v", err)
 	}
+}
+%[4]s`, variable, currentFolderName, structName, deleteTests, modelVariable(inflection.Pluralize(structName)),
+		key.Zero, std, key.MissingID, external, modelName)
 
-	// Extract the current content inside db.AutoMigrate
-	migrateContent := strings.TrimSpace(content[start : start+end])
+	writer.WriteString(content)
+	writer.Flush()
+	return nil
+}
 
-	// Clean up trailing commas in the existing AutoMigrate entries
-	if strings.HasSuffix(migrateContent, ",") {
-		migrateContent = strings.TrimSuffix(migrateContent, ",")
+// repositoryTestImports returns the import lines of the standard library and of the other packages,
+// besides GORM and SQLite, used by the repository tests: the package of the key type and the ones
+// of the values of unique fields.
+func repositoryTestImports(spec ModelSpec, key KeyType) (string, string) {
+	code := repositoryTestModel(spec) + key.GoType + key.Zero + key.MissingID
+	std := []string{"context", "errors", "testing"}
+	for _, pkg := range []string{"fmt", "time"} {
+		if strings.Contains(code, pkg+".") {
+			std = append(std, pkg)
+		}
 	}
+	sort.Strings(std)
 
-	// Add the new entry
-	if migrateContent == "" {
-		// If AutoMigrate is empty, add the entry
-		migrateContent = "\n\t\t" + entry
-	} else {
-		// If AutoMigrate has existing entries, append the new entry
-		migrateContent += ",\n\t\t" + entry
+	var external []string
+	_, packages := fieldImports(append(spec.Fields, ModelField{Type: key.GoType}))
+	if key.Import != "" && !slices.Contains(packages, key.Import) {
+		packages = append(packages, key.Import)
+		sort.Strings(packages)
+	}
+	for _, pkg := range packages {
+		if strings.Contains(code, FieldType{Import: pkg}.PackageName()+".") {
+			external = append(external, fmt.Sprintf("\t%q\n", pkg))
+		}
 	}
 
-	// Replace the content inside db.AutoMigrate
-	return content[:start] + migrateContent + content[start+end:]
+	lines := make([]string, len(std))
+	for i, pkg := range std {
+		lines[i] = fmt.Sprintf("\t%q\n", pkg)
+	}
+	return strings.Join(lines, ""), strings.Join(external, "")
 }
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// ScaffoldModel generates every layer of a hand-written model struct, which stays the source of truth:
+// the domain model file is read, never written.
+func ScaffoldModel(modelName string) error {
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+
+	model, err := FindModel(models, utils.ToPascalCase(modelName))
+	if err != nil {
+		return err
+	}
+
+	spec, options, err := structModelSpec(*model, modelStructs(models))
+	if err != nil {
+		return err
+	}
+	return generateLayers(utils.ToCamelCase(model.Name), spec, options)
+}
+
+// structModelSpec maps a parsed model struct to the spec and options of its layers.
+// The struct needs an ID uint field, declared or embedded through gorm.Model.
+func structModelSpec(model ModelStruct, structs map[string][]*ast.Field) (ModelSpec, ModelOptions, error) {
+	spec := ModelSpec{Name: model.Name, TableName: model.TableName}
+	options := ModelOptions{}
+
+	// Foreign keys are the fields a belongs-to relationship points at
+	foreignKeys := map[string]string{}
+	for _, relation := range modelRelations(model, structs) {
+		if relation.ForeignKey != "" {
+			foreignKeys[relation.ForeignKey] = relation.Model
+		}
+	}
+
+	hasID := false
+	for _, field := range model.Fields {
+		goType := types.ExprString(field.Type)
+		tag := gormTag(field)
+		if _, ignored := tag["-"]; ignored {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			if goType == "gorm.Model" {
+				hasID, spec.Timestamps, options.SoftDelete = true, true, true
+				options.Key = "uint"
+				continue
+			}
+			return spec, options, fmt.Errorf("embedded field %s of %s is not supported", goType, model.Name)
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			switch {
+			case name.Name == "ID":
+				key, ok := structKeyType(goType)
+				if !ok {
+					return spec, options, fmt.Errorf("the ID field of %s has to be a uint, int64, uuid.UUID or string, found %s", model.Name, goType)
+				}
+				// Keys the database does not assign are set by a hook of the struct
+				if key.New != "" && !slices.Contains(model.Methods, "BeforeCreate") {
+					return spec, options, fmt.Errorf("the ID field of %s is a %s, add a BeforeCreate method setting it to %s", model.Name, goType, key.New)
+				}
+				hasID = true
+				options.Key = key.Name
+				spec.IDColumn = tag["column"]
+				continue
+			case name.Name == "CreatedAt" || name.Name == "UpdatedAt":
+				spec.Timestamps = true
+				continue
+			case goType == "gorm.DeletedAt":
+				options.SoftDelete = true
+				continue
+			}
+
+			// Relationships are filled through their foreign key
+			if _, isModel := structs[strings.TrimPrefix(strings.TrimPrefix(goType, "[]"), "*")]; isModel {
+				continue
+			}
+			modelField, err := structModelField(name.Name, goType, field, tag)
+			if err != nil {
+				fmt.Printf("Skipping field %s.%s: %v\n", model.Name, name.Name, err)
+				continue
+			}
+			modelField.Relation = foreignKeys[modelField.Name]
+			spec.Fields = append(spec.Fields, modelField)
+		}
+	}
+
+	if !hasID {
+		return spec, options, fmt.Errorf("model %s has no ID field", model.Name)
+	}
+	return spec, options, nil
+}
+
+// structModelField maps a struct field to a ModelField. Fields hidden from JSON are hidden fields, and
+// fields whose type cannot be used in the inbound model, like slices and types of other packages, are not mapped.
+func structModelField(name, goType string, field *ast.Field, tag map[string]string) (ModelField, error) {
+	result := ModelField{Name: name, Nullable: strings.HasPrefix(goType, "*")}
+	result.Type = strings.TrimPrefix(goType, "*")
+
+	_, registered := LookupFieldType(result.Type)
+	switch {
+	case registered:
+	case len(structEnumValues(field)) > 0:
+		result.Enum = true
+	case strings.ContainsAny(result.Type, ".[]*"), types.Universe.Lookup(result.Type) == nil:
+		return result, fmt.Errorf("type %s is not supported in the inbound model", goType)
+	}
+
+	// Columns are kept explicit when they differ from the ones of the naming strategy
+	column := tag["column"]
+	if column == "" {
+		column = toColumnName(name)
+	}
+	if column != result.ColumnName() {
+		result.Column = column
+	}
+	result.Default = modelDefault(result, tag["default"])
+	result.Description = structFieldDescription(field, tag)
+	result.Unique, result.Indexed, result.Index = tagIndex(tag)
+	result.Size, _ = strconv.Atoi(tag["size"])
+
+	// Keys hidden from JSON stay out of the requests and responses
+	if field.Tag != nil {
+		value, _ := strconv.Unquote(field.Tag.Value)
+		jsonName := strings.Split(reflect.StructTag(value).Get("json"), ",")[0]
+		result.Access = reflect.StructTag(value).Get("access")
+		if jsonName == "-" {
+			result.Access = "hidden"
+		} else if jsonName != "" && jsonName != result.JSONName() {
+			result.JSON = jsonName
+		}
+		result.Rules = parseRulesTag(reflect.StructTag(value).Get("rules"))
+
+		// Examples are kept unless they are the defaults shown as examples
+		if example := reflect.StructTag(value).Get("example"); example != fieldExample(result) {
+			result.Example = example
+		}
+	}
+	// The model keeps its rules tag, only the validator leaves out a required check it cannot enforce
+	if err := validateRequired(result); err != nil {
+		fmt.Printf("Ignoring the required rule: %v\n", err)
+		result.Rules.Required = false
+	}
+	return result, validateAccess(result)
+}
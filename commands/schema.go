@@ -0,0 +1,430 @@
+package commands
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/inflection"
+)
+
+// Schema is the database view of every model in a project.
+type Schema struct {
+	Tables []Table `json:"tables"`
+}
+
+// Table is the database view of a single model struct.
+type Table struct {
+	Name    string   `json:"name"`
+	Model   string   `json:"model"`
+	Columns []Column `json:"columns"`
+	Indexes []Index  `json:"indexes,omitempty"`
+}
+
+// Index is a named index of a table, on one or more of its columns.
+type Index struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique,omitempty"`
+}
+
+// Column is the database view of a single model field.
+type Column struct {
+	Name          string `json:"name"`
+	GoType        string `json:"go_type"`
+	SQLType       string `json:"sql_type,omitempty"`
+	NotNull       bool   `json:"not_null,omitempty"`
+	PrimaryKey    bool   `json:"primary_key,omitempty"`
+	AutoIncrement bool   `json:"auto_increment,omitempty"`
+	Default       string `json:"default,omitempty"`
+	Index         bool   `json:"index,omitempty"`
+	Unique        bool   `json:"unique,omitempty"`
+	Size          int    `json:"size,omitempty"`
+	Check         string `json:"check,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+
+	// The named index the column is part of, gathered into the indexes of its table
+	indexName   string
+	indexUnique bool
+}
+
+// Table returns the table with the given name, or nil when the schema does not have it.
+func (s *Schema) Table(name string) *Table {
+	for i := range s.Tables {
+		if s.Tables[i].Name == name {
+			return &s.Tables[i]
+		}
+	}
+	return nil
+}
+
+// Index returns the named index with the given name, or nil when the table does not have it.
+func (t *Table) Index(name string) *Index {
+	for i := range t.Indexes {
+		if t.Indexes[i].Name == name {
+			return &t.Indexes[i]
+		}
+	}
+	return nil
+}
+
+// Column returns the column with the given name, or nil when the table does not have it.
+func (t *Table) Column(name string) *Column {
+	for i := range t.Columns {
+		if t.Columns[i].Name == name {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// ModelStruct is a model struct parsed from the domain model package.
+type ModelStruct struct {
+	Name      string
+	TableName string
+	Fields    []*ast.Field
+	Methods   []string
+}
+
+// ReadModels parses the struct types declared in dir, in declaration order.
+// TableName is filled in for structs that declare a TableName method, and Methods lists the methods of each struct.
+func ReadModels(dir string) ([]ModelStruct, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing model files: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	var models []ModelStruct
+	tableNames := map[string]string{}
+	methods := map[string][]string{}
+
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+						models = append(models, ModelStruct{Name: typeSpec.Name.Name, Fields: structType.Fields.List})
+					}
+				}
+			case *ast.FuncDecl:
+				if name, table, ok := tableNameMethod(decl); ok {
+					tableNames[name] = table
+				}
+				if receiver := receiverName(decl); receiver != "" {
+					methods[receiver] = append(methods[receiver], decl.Name.Name)
+				}
+			}
+		}
+	}
+
+	for i := range models {
+		models[i].TableName = tableNames[models[i].Name]
+		models[i].Methods = methods[models[i].Name]
+	}
+	return models, nil
+}
+
+// FindModel returns the model struct with the given name.
+func FindModel(models []ModelStruct, name string) (*ModelStruct, error) {
+	for i := range models {
+		if models[i].Name == name {
+			return &models[i], nil
+		}
+	}
+	return nil, fmt.Errorf("model %s not found", name)
+}
+
+// modelStructs indexes the fields of the parsed models by struct name.
+func modelStructs(models []ModelStruct) map[string][]*ast.Field {
+	structs := map[string][]*ast.Field{}
+	for _, model := range models {
+		structs[model.Name] = model.Fields
+	}
+	return structs
+}
+
+// ReadModelSchema parses the model structs in dir and returns the tables they map to.
+// Relationship fields are skipped since they don't own a column.
+func ReadModelSchema(dir string) (*Schema, error) {
+	models, err := ReadModels(dir)
+	if err != nil {
+		return nil, err
+	}
+	structs := modelStructs(models)
+
+	schema := &Schema{}
+	for _, model := range models {
+		table := Table{Name: model.TableName, Model: model.Name}
+		for _, field := range model.Fields {
+			table.Columns = append(table.Columns, fieldColumns(field, structs)...)
+		}
+		for _, column := range table.Columns {
+			if column.indexName == "" {
+				continue
+			}
+			if index := table.Index(column.indexName); index != nil {
+				index.Columns = append(index.Columns, column.Name)
+				index.Unique = index.Unique || column.indexUnique
+				continue
+			}
+			table.Indexes = append(table.Indexes, Index{Name: column.indexName, Columns: []string{column.Name}, Unique: column.indexUnique})
+		}
+
+		// Only structs that declare a table name or own a primary key are tables
+		if table.Name == "" {
+			if !hasPrimaryKey(table.Columns) || !ast.IsExported(model.Name) {
+				continue
+			}
+			table.Name = inflection.Pluralize(toColumnName(model.Name))
+		}
+		schema.Tables = append(schema.Tables, table)
+	}
+
+	sort.Slice(schema.Tables, func(i, j int) bool { return schema.Tables[i].Name < schema.Tables[j].Name })
+	return schema, nil
+}
+
+// tableNameMethod reports the receiver and returned literal of a `TableName() string` method.
+func tableNameMethod(decl *ast.FuncDecl) (string, string, bool) {
+	if decl.Name.Name != "TableName" || decl.Recv == nil || len(decl.Recv.List) != 1 || decl.Body == nil {
+		return "", "", false
+	}
+
+	name := receiverName(decl)
+	if name == "" {
+		return "", "", false
+	}
+
+	for _, stmt := range decl.Body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		if lit, ok := ret.Results[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			table, err := strconv.Unquote(lit.Value)
+			if err == nil {
+				return name, table, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// fieldColumns returns the columns a struct field maps to.
+// Embedded structs are flattened and relationship fields return nothing.
+func fieldColumns(field *ast.Field, structs map[string][]*ast.Field) []Column {
+	goType := types.ExprString(field.Type)
+	tag := gormTag(field)
+
+	if _, ignored := tag["-"]; ignored {
+		return nil
+	}
+
+	// Embedded fields: gorm.Model or a struct declared next to the models
+	if len(field.Names) == 0 {
+		if goType == "gorm.Model" {
+			return []Column{
+				{Name: "id", GoType: "uint", NotNull: true, PrimaryKey: true, AutoIncrement: true},
+				{Name: "created_at", GoType: "time.Time"},
+				{Name: "updated_at", GoType: "time.Time"},
+				{Name: "deleted_at", GoType: "gorm.DeletedAt", Index: true},
+			}
+		}
+		var columns []Column
+		if embedded, ok := structs[strings.TrimPrefix(goType, "*")]; ok {
+			for _, embeddedField := range embedded {
+				columns = append(columns, fieldColumns(embeddedField, structs)...)
+			}
+		}
+		return columns
+	}
+
+	// Fields pointing to other models are relationships, not columns
+	baseType := strings.TrimPrefix(strings.TrimPrefix(goType, "[]"), "*")
+	if _, isModel := structs[baseType]; isModel {
+		return nil
+	}
+	if strings.HasPrefix(goType, "[]") && goType != "[]byte" {
+		return nil
+	}
+	if _, isRelation := tag["foreignKey"]; isRelation {
+		return nil
+	}
+
+	var columns []Column
+	for _, name := range field.Names {
+		if !name.IsExported() {
+			continue
+		}
+
+		column := Column{
+			Name:    toColumnName(name.Name),
+			GoType:  strings.TrimPrefix(goType, "*"),
+			SQLType: tag["type"],
+			Check:   tag["check"],
+			Comment: tag["comment"],
+		}
+		// Enums are stored as strings
+		if len(structEnumValues(field)) > 0 {
+			column.GoType = "string"
+		}
+		column.Default = columnDefault(column.GoType, tag["default"])
+		if value, ok := tag["column"]; ok && value != "" {
+			column.Name = value
+		}
+
+		_, primaryKey := tag["primaryKey"]
+		column.PrimaryKey = primaryKey || name.Name == "ID"
+
+		_, autoIncrement := tag["autoIncrement"]
+		column.AutoIncrement = autoIncrement || (column.PrimaryKey && isIntegerType(column.GoType))
+
+		_, notNull := tag["not null"]
+		column.NotNull = notNull || column.PrimaryKey
+
+		unique, indexed, indexName := tagIndex(tag)
+		column.Unique, column.Index = unique && indexName == "", indexed
+		column.indexName, column.indexUnique = indexName, unique && indexName != ""
+		column.Size, _ = strconv.Atoi(tag["size"])
+
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// gormTag parses the gorm struct tag of a field into its settings.
+// Keys are matched case-insensitively and normalized to the spelling used in the generated models.
+func gormTag(field *ast.Field) map[string]string {
+	settings := map[string]string{}
+	if field.Tag == nil {
+		return settings
+	}
+
+	raw, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return settings
+	}
+
+	value, ok := reflect.StructTag(raw).Lookup("gorm")
+	if !ok {
+		return settings
+	}
+
+	canonical := map[string]string{
+		"primarykey":    "primaryKey",
+		"primary_key":   "primaryKey",
+		"autoincrement": "autoIncrement",
+		"not null":      "not null",
+		"foreignkey":    "foreignKey",
+		"uniqueindex":   "uniqueIndex",
+	}
+
+	// Semicolons escaped with a backslash, like in defaults, do not end a setting
+	var parts []string
+	for _, part := range strings.Split(value, ";") {
+		if n := len(parts); n > 0 && strings.HasSuffix(parts[n-1], `\`) {
+			parts[n-1] = strings.TrimSuffix(parts[n-1], `\`) + ";" + part
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, setting, _ := strings.Cut(part, ":")
+		key = strings.TrimSpace(key)
+		if name, ok := canonical[strings.ToLower(key)]; ok {
+			key = name
+		} else if key != "-" {
+			key = strings.ToLower(key)
+		}
+		settings[key] = strings.TrimSpace(setting)
+	}
+	return settings
+}
+
+// tagIndex returns how the settings of a gorm tag index a column: with a unique constraint or index, with
+// an index of its own, or through the named index given by "index:name" or "uniqueIndex:name", which other
+// columns can share to make a composite index.
+func tagIndex(tag map[string]string) (unique, indexed bool, name string) {
+	_, unique = tag["unique"]
+	if setting, ok := tag["uniqueIndex"]; ok {
+		unique = true
+		name = setting
+	}
+	if setting, ok := tag["index"]; ok {
+		// Index options follow the name, like in "index:idx_name,unique"
+		options := strings.Split(setting, ",")
+		name = strings.TrimSpace(options[0])
+		for _, option := range options[1:] {
+			unique = unique || strings.EqualFold(strings.TrimSpace(option), "unique")
+		}
+		indexed = name == "" && !unique
+	}
+	return unique, indexed, name
+}
+
+// hasPrimaryKey reports whether any of the columns is a primary key.
+func hasPrimaryKey(columns []Column) bool {
+	for _, column := range columns {
+		if column.PrimaryKey {
+			return true
+		}
+	}
+	return false
+}
+
+// isIntegerType reports whether goType is one of Go's integer types.
+func isIntegerType(goType string) bool {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// toColumnName converts a Go field name to the column name GORM derives from it.
+// Runs of capitals are kept together, so "UserID" becomes "user_id".
+func toColumnName(name string) string {
+	return inflection.Underscore(name)
+}
+
+// receiverName returns the name of the type a method is declared on, or an empty string for functions.
+func receiverName(decl *ast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) != 1 {
+		return ""
+	}
+	receiver := decl.Recv.List[0].Type
+	if star, ok := receiver.(*ast.StarExpr); ok {
+		receiver = star.X
+	}
+	if ident, ok := receiver.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
@@ -9,7 +9,7 @@ import (
 )
 
 // GenerateService generates Go service files for a given model name.
-func GenerateService(modelName, structName string) error {
+func GenerateService(modelName, structName string, options ModelOptions) error {
 	// Get the current working directory
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -29,13 +29,13 @@ func GenerateService(modelName, structName string) error {
 
 	// Generate the Service interface file
 	serviceFilePath := filepath.Join(serviceDir, fmt.Sprintf("%sService.go", modelName))
-	if err := writeServiceInterfaceFile(serviceFilePath, currentFolderName, modelName, structName); err != nil {
+	if err := writeServiceInterfaceFile(serviceFilePath, currentFolderName, modelName, structName, options); err != nil {
 		return fmt.Errorf("error writing service interface file: %v", err)
 	}
 
 	// Generate the Service implementation file
 	serviceImplFilePath := filepath.Join(serviceDir, fmt.Sprintf("%sServiceImpl.go", modelName))
-	if err := writeServiceImplFile(serviceImplFilePath, currentFolderName, modelName, structName); err != nil {
+	if err := writeServiceImplFile(serviceImplFilePath, currentFolderName, modelName, structName, options); err != nil {
 		return fmt.Errorf("error writing service implementation file: %v", err)
 	}
 
@@ -50,7 +50,7 @@ func GenerateService(modelName, structName string) error {
 }
 
 // writeServiceInterfaceFile creates the service interface file.
-func writeServiceInterfaceFile(filePath, currentFolderName, modelName, structName string) error {
+func writeServiceInterfaceFile(filePath, currentFolderName, modelName, structName string, options ModelOptions) error {
 	file, err := os.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("error creating file: %v", err)
@@ -58,20 +58,34 @@ func writeServiceInterfaceFile(filePath, currentFolderName, modelName, structNam
 	defer file.Close()
 
 	writer := bufio.NewWriter(file)
+	key := options.key()
+
+	// Soft-deleted models can also be restored, listed from the trash and purged
+	softDeleteMethods := ""
+	if options.SoftDelete {
+		softDeleteMethods = fmt.Sprintf(`	Restore(ctx context.Context, id %[2]s) error
+	FindTrashed(ctx context.Context) ([]*model.%[1]s, error)
+	ForceDelete(ctx context.Context, id %[2]s) error
+`, structName, key.GoType)
+	}
 
 	// Write the content
-	content := fmt.Sprintf(`package %sService
+	content := fmt.Sprintf(`package %[1]sService
+
+import (
+	"context"
 
-import "%s/internal/app/domain/model"
+	"%[2]s/internal/app/domain/model"
+%[6]s)
 
-type %sService interface {
-	Create(%s *model.%s) error
-	Update(id uint, %s *model.%s) error
-	Delete(id uint) error
-	FindAll() ([]*model.%s, error)
-	FindById(id uint) (*model.%s, error)
-}
-`, modelName, currentFolderName, structName, modelName, structName, modelName, structName, structName, structName)
+type %[3]sService interface {
+	Create(ctx context.Context, %[7]s *model.%[3]s) error
+	Update(ctx context.Context, id %[5]s, %[7]s *model.%[3]s) error
+	Delete(ctx context.Context, id %[5]s) error
+	FindAll(ctx context.Context) ([]*model.%[3]s, error)
+	FindById(ctx context.Context, id %[5]s) (*model.%[3]s, error)
+%[4]s}
+`, modelName, currentFolderName, structName, softDeleteMethods, key.GoType, key.ImportLine(key.GoType), modelVariable(modelName))
 
 	writer.WriteString(content)
 	writer.Flush()
@@ -79,7 +93,7 @@ type %sService interface {
 }
 
 // writeServiceImplFile creates the service implementation file.
-func writeServiceImplFile(filePath, currentFolderName, modelName, structName string) error {
+func writeServiceImplFile(filePath, currentFolderName, modelName, structName string, options ModelOptions) error {
 	file, err := os.Create(filePath)
 	if err != nil {
 		return fmt.Errorf("error creating file: %v", err)
@@ -87,73 +101,65 @@ func writeServiceImplFile(filePath, currentFolderName, modelName, structName str
 	defer file.Close()
 
 	writer := bufio.NewWriter(file)
+	key := options.key()
+
+	softDeleteMethods := ""
+	if options.SoftDelete {
+		softDeleteMethods = fmt.Sprintf(`
+func (s *%[1]sServiceImpl) Restore(ctx context.Context, id %[2]s) error {
+	return s.repository.Restore(ctx, id)
+}
+
+func (s *%[1]sServiceImpl) FindTrashed(ctx context.Context) ([]*model.%[1]s, error) {
+	return s.repository.FindTrashed(ctx)
+}
+
+func (s *%[1]sServiceImpl) ForceDelete(ctx context.Context, id %[2]s) error {
+	return s.repository.ForceDelete(ctx, id)
+}
+`, structName, key.GoType)
+	}
 
 	// Write the content
-	content := fmt.Sprintf(`package %sService
+	content := fmt.Sprintf(`package %[1]sService
 
 import (
-	"%s/internal/app/domain/model"
-	%sRepository "%s/internal/app/domain/repository/%s"
-)
+	"context"
+
+	"%[2]s/internal/app/domain/model"
+	%[1]sRepository "%[2]s/internal/app/domain/repository/%[1]s"
+%[6]s)
 
-var _ %sService = (*%sServiceImpl)(nil)
+var _ %[3]sService = (*%[3]sServiceImpl)(nil)
 
-type %sServiceImpl struct {
-	repository %sRepository.%sRepository
+type %[3]sServiceImpl struct {
+	repository %[1]sRepository.%[3]sRepository
 }
 
-func New%sService(repository %sRepository.%sRepository) *%sServiceImpl {
-	return &%sServiceImpl{repository: repository}
+func New%[3]sService(repository %[1]sRepository.%[3]sRepository) *%[3]sServiceImpl {
+	return &%[3]sServiceImpl{repository: repository}
 }
 
-func (s *%sServiceImpl) Create(%s *model.%s) error {
-	return s.repository.Create(%s)
+func (s *%[3]sServiceImpl) Create(ctx context.Context, %[7]s *model.%[3]s) error {
+	return s.repository.Create(ctx, %[7]s)
 }
 
-func (s *%sServiceImpl) Update(id uint, %s *model.%s) error {
-	return s.repository.Update(id, %s)
+func (s *%[3]sServiceImpl) Update(ctx context.Context, id %[5]s, %[7]s *model.%[3]s) error {
+	return s.repository.Update(ctx, id, %[7]s)
 }
 
-func (s *%sServiceImpl) Delete(id uint) error {
-	return s.repository.Delete(id)
+func (s *%[3]sServiceImpl) Delete(ctx context.Context, id %[5]s) error {
+	return s.repository.Delete(ctx, id)
 }
 
-func (s *%sServiceImpl) FindAll() ([]*model.%s, error) {
-	return s.repository.FindAll()
+func (s *%[3]sServiceImpl) FindAll(ctx context.Context) ([]*model.%[3]s, error) {
+	return s.repository.FindAll(ctx)
 }
 
-func (s *%sServiceImpl) FindById(id uint) (*model.%s, error) {
-	return s.repository.FindById(id)
+func (s *%[3]sServiceImpl) FindById(ctx context.Context, id %[5]s) (*model.%[3]s, error) {
+	return s.repository.FindById(ctx, id)
 }
-`,
-		modelName,
-		currentFolderName,
-		modelName,
-		currentFolderName,
-		modelName,
-		structName,
-		structName,
-		structName,
-		modelName,
-		structName,
-		structName,
-		modelName,
-		structName,
-		structName,
-		structName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		structName,
-		modelName,
-		structName,
-		structName,
-		structName,
-		structName,
-		structName)
+%[4]s`, modelName, currentFolderName, structName, softDeleteMethods, key.GoType, key.ImportLine(key.GoType), modelVariable(modelName))
 
 	writer.WriteString(content)
 	writer.Flush()
@@ -223,8 +229,9 @@ func editServicesFile(servicesFile, modelName, structName, currentFolderName str
 		lines = insertLineAfter(lines, "import (", importService)
 	}
 
-	// Add the service field in the Services struct if not present
-	serviceField := fmt.Sprintf("\t%sService *%sService.%sServiceImpl", structName, modelName, structName)
+	// Add the service field in the Services struct if not present.
+	// The field holds the interface so handlers can be tested against a mock.
+	serviceField := fmt.Sprintf("\t%sService %sService.%sService", structName, modelName, structName)
 	if !strings.Contains(string(content), serviceField) {
 		lines = insertLineAfter(lines, "type Services struct {", serviceField)
 	}
@@ -232,7 +239,9 @@ func editServicesFile(servicesFile, modelName, structName, currentFolderName str
 	// Add initialization in the `services := &Services{}` block
 	initLine := fmt.Sprintf("\t\t%sService: %sService.New%sService(%sRepository.New%sRepository(dbs)),",
 		structName, modelName, structName, modelName, structName)
-	lines = addLineToNewServicesBlock(lines, initLine)
+	if !strings.Contains(string(content), initLine) {
+		lines = addLineToNewServicesBlock(lines, initLine)
+	}
 
 	// Write the updated content back to services.go
 	return os.WriteFile(servicesFile, []byte(strings.Join(lines, "\n")), 0644)
@@ -0,0 +1,277 @@
+package commands
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// sqliteHeader is the magic string every SQLite 3 database file starts with.
+const sqliteHeader = "SQLite format 3\x00"
+
+// sqliteFile reads the b-tree pages of a SQLite database file.
+// Only what is needed to walk the sqlite_master table is supported.
+type sqliteFile struct {
+	data       []byte
+	pageSize   int
+	usableSize int
+	visited    map[int]bool // pages already read, as a page belongs to a single b-tree or overflow chain
+}
+
+// ReadSQLiteDDL returns the CREATE TABLE and CREATE INDEX statements stored in a SQLite database file.
+// Internal tables and indexes and the schema_migrations table of the migration runner are skipped.
+// Databases with changes left in their write-ahead log are refused, as the log is not read.
+func ReadSQLiteDDL(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %v", path, err)
+	}
+	if len(data) < 100 || string(data[:16]) != sqliteHeader {
+		return "", fmt.Errorf("%s is not a SQLite 3 database", path)
+	}
+	if encoding := binary.BigEndian.Uint32(data[56:60]); encoding > 1 {
+		return "", fmt.Errorf("%s uses a UTF-16 text encoding, only UTF-8 databases are supported", path)
+	}
+	if info, err := os.Stat(path + "-wal"); err == nil && info.Size() > 0 {
+		return "", fmt.Errorf("%s has changes in %s-wal that are not checkpointed, run `sqlite3 %s \"PRAGMA wal_checkpoint(TRUNCATE)\"` or close the applications using it first", path, path, path)
+	}
+
+	db := &sqliteFile{data: data, pageSize: int(binary.BigEndian.Uint16(data[16:18])), visited: map[int]bool{}}
+	if db.pageSize == 1 {
+		db.pageSize = 65536
+	}
+	db.usableSize = db.pageSize - int(data[20])
+	if db.pageSize < 512 || db.usableSize < 480 {
+		return "", fmt.Errorf("%s has an invalid page size", path)
+	}
+
+	// sqlite_master is the table b-tree rooted at page 1
+	var statements []string
+	err = db.walkTable(1, func(record []interface{}) error {
+		if len(record) < 5 {
+			return nil
+		}
+		recordType, _ := record[0].(string)
+		name, _ := record[1].(string)
+		table, _ := record[2].(string)
+		sql, _ := record[4].(string)
+		if (recordType != "table" && recordType != "index") || strings.HasPrefix(name, "sqlite_") || table == "schema_migrations" || sql == "" {
+			return nil
+		}
+		statements = append(statements, sql+";")
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading the schema of %s: %v", path, err)
+	}
+	return strings.Join(statements, "\n"), nil
+}
+
+// page returns the content of a page, numbered from 1. Reading a page twice is an error, so corrupted
+// files pointing back to a page already read do not loop.
+func (db *sqliteFile) page(number int) ([]byte, error) {
+	start := (number - 1) * db.pageSize
+	if number < 1 || start+db.pageSize > len(db.data) {
+		return nil, fmt.Errorf("page %d is out of range", number)
+	}
+	if db.visited[number] {
+		return nil, fmt.Errorf("page %d is referenced twice", number)
+	}
+	db.visited[number] = true
+	return db.data[start : start+db.pageSize], nil
+}
+
+// walkTable calls visit with the decoded record of every row of the table b-tree rooted at root.
+func (db *sqliteFile) walkTable(root int, visit func(record []interface{}) error) error {
+	page, err := db.page(root)
+	if err != nil {
+		return err
+	}
+
+	// The first page holds the database header before its b-tree header
+	header := 0
+	if root == 1 {
+		header = 100
+	}
+
+	// cell returns the offset of the i-th cell of the page, checking it fits in the page
+	cell := func(pointers, i, size int) (int, error) {
+		if pointers+2*i+2 > len(page) {
+			return 0, fmt.Errorf("page %d has more cells than fit in it", root)
+		}
+		offset := int(binary.BigEndian.Uint16(page[pointers+2*i:]))
+		if offset < pointers || offset+size > db.usableSize {
+			return 0, fmt.Errorf("page %d has a cell out of range", root)
+		}
+		return offset, nil
+	}
+
+	pageType := page[header]
+	cells := int(binary.BigEndian.Uint16(page[header+3 : header+5]))
+	switch pageType {
+	case 0x05: // interior table page
+		pointers := header + 12
+		for i := 0; i < cells; i++ {
+			offset, err := cell(pointers, i, 4)
+			if err != nil {
+				return err
+			}
+			if err := db.walkTable(int(binary.BigEndian.Uint32(page[offset:])), visit); err != nil {
+				return err
+			}
+		}
+		return db.walkTable(int(binary.BigEndian.Uint32(page[header+8:])), visit)
+	case 0x0d: // leaf table page
+		pointers := header + 8
+		for i := 0; i < cells; i++ {
+			offset, err := cell(pointers, i, 2)
+			if err != nil {
+				return err
+			}
+			payload, err := db.cellPayload(page[:db.usableSize], offset)
+			if err != nil {
+				return fmt.Errorf("page %d: %v", root, err)
+			}
+			record, err := decodeSQLiteRecord(payload)
+			if err != nil {
+				return err
+			}
+			if err := visit(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("page %d is not a table b-tree page", root)
+}
+
+// cellPayload returns the payload of a leaf table cell, following its overflow pages.
+func (db *sqliteFile) cellPayload(page []byte, cell int) ([]byte, error) {
+	size, n := sqliteVarint(page[cell:])
+	cell += n
+	_, n = sqliteVarint(page[cell:]) // rowid
+	cell += n
+
+	total := int(size)
+	if size > uint64(len(db.data)) {
+		return nil, fmt.Errorf("cell payload larger than the file")
+	}
+	maxLocal := db.usableSize - 35
+	if total <= maxLocal {
+		if cell+total > len(page) {
+			return nil, fmt.Errorf("cell payload out of range")
+		}
+		return page[cell : cell+total], nil
+	}
+
+	minLocal := (db.usableSize-12)*32/255 - 23
+	local := minLocal + (total-minLocal)%(db.usableSize-4)
+	if local > maxLocal {
+		local = minLocal
+	}
+	if cell+local+4 > len(page) {
+		return nil, fmt.Errorf("cell payload out of range")
+	}
+
+	payload := append([]byte{}, page[cell:cell+local]...)
+	next := int(binary.BigEndian.Uint32(page[cell+local:]))
+	for len(payload) < total {
+		if next == 0 {
+			return nil, fmt.Errorf("cell payload overflow chain ends early")
+		}
+		overflow, err := db.page(next)
+		if err != nil {
+			return nil, err
+		}
+		chunk := overflow[4:db.usableSize]
+		if remaining := total - len(payload); len(chunk) > remaining {
+			chunk = chunk[:remaining]
+		}
+		payload = append(payload, chunk...)
+		next = int(binary.BigEndian.Uint32(overflow))
+	}
+	return payload, nil
+}
+
+// decodeSQLiteRecord decodes a record into int64, float64, string, []byte and nil values.
+func decodeSQLiteRecord(payload []byte) ([]interface{}, error) {
+	headerSize, n := sqliteVarint(payload)
+	if int(headerSize) > len(payload) {
+		return nil, fmt.Errorf("corrupted record header")
+	}
+
+	var serialTypes []uint64
+	for offset := n; offset < int(headerSize); {
+		serialType, n := sqliteVarint(payload[offset:])
+		serialTypes = append(serialTypes, serialType)
+		offset += n
+	}
+
+	var values []interface{}
+	body := payload[headerSize:]
+	for _, serialType := range serialTypes {
+		var size int
+		switch {
+		case serialType == 0, serialType == 8, serialType == 9:
+			size = 0
+		case serialType <= 4:
+			size = int(serialType)
+		case serialType == 5:
+			size = 6
+		case serialType == 6, serialType == 7:
+			size = 8
+		case serialType >= 12:
+			size = int(serialType-12) / 2
+		default:
+			return nil, fmt.Errorf("unsupported serial type %d", serialType)
+		}
+		if size < 0 || size > len(body) {
+			return nil, fmt.Errorf("corrupted record body")
+		}
+
+		value := body[:size]
+		body = body[size:]
+		switch {
+		case serialType == 0:
+			values = append(values, nil)
+		case serialType == 7:
+			values = append(values, math.Float64frombits(binary.BigEndian.Uint64(value)))
+		case serialType == 8:
+			values = append(values, int64(0))
+		case serialType == 9:
+			values = append(values, int64(1))
+		case serialType <= 6:
+			// Big-endian two's complement integers of 1 to 8 bytes
+			var number int64
+			if value[0]&0x80 != 0 {
+				number = -1
+			}
+			for _, b := range value {
+				number = number<<8 | int64(b)
+			}
+			values = append(values, number)
+		case serialType%2 == 0:
+			values = append(values, value)
+		default:
+			values = append(values, string(value))
+		}
+	}
+	return values, nil
+}
+
+// sqliteVarint decodes a SQLite variable-length integer and returns it with its length in bytes.
+func sqliteVarint(data []byte) (uint64, int) {
+	var value uint64
+	for i := 0; i < 9 && i < len(data); i++ {
+		if i == 8 {
+			return value<<8 | uint64(data[i]), 9
+		}
+		value = value<<7 | uint64(data[i]&0x7f)
+		if data[i]&0x80 == 0 {
+			return value, i + 1
+		}
+	}
+	return value, len(data)
+}
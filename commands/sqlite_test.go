@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// testdata/shop.db has 512 byte pages: its sqlite_master table is rooted at an interior page, and the
+// statement of the products table overflows its leaf page.
+const sqliteFixture = "testdata/shop.db"
+
+func TestReadSQLiteDDL(t *testing.T) {
+	script, err := ReadSQLiteDDL(sqliteFixture)
+	if err != nil {
+		t.Fatalf("ReadSQLiteDDL() error = %v", err)
+	}
+	tables, err := ParseDDL(script)
+	if err != nil {
+		t.Fatalf("ParseDDL() error = %v", err)
+	}
+	table := func(name string) *DDLTable {
+		index := slices.IndexFunc(tables, func(table DDLTable) bool { return table.Name == name })
+		if index < 0 {
+			t.Fatalf("table %s not found", name)
+		}
+		return &tables[index]
+	}
+
+	// The tables of every leaf page are read, without the internal ones and the migrations table
+	var names []string
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+	slices.Sort(names)
+	if want := []string{"customers", "orders", "products"}; !slices.Equal(names, want) {
+		t.Fatalf("tables = %q, want %q", names, want)
+	}
+
+	tests := []struct {
+		table  string
+		column DDLColumn
+	}{
+		{"customers", DDLColumn{Name: "email", Type: "varchar(120)", NotNull: true, Unique: true}},
+		{"orders", DDLColumn{Name: "customer_id", Type: "integer", NotNull: true, References: "customers"}},
+		{"orders", DDLColumn{Name: "status", Type: "text", NotNull: true, Default: "open"}},
+		// The end of the products statement is stored in an overflow page, and its line comments
+		// document the columns
+		{"products", DDLColumn{Name: "sku", Type: "varchar(32)", NotNull: true, Unique: true, Comment: "stock keeping unit printed on the labels"}},
+		{"products", DDLColumn{Name: "stock", Type: "integer", NotNull: true, Default: "0", Comment: "units left in the warehouse, updated by the stock service"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.table+"."+tt.column.Name, func(t *testing.T) {
+			column := table(tt.table).Column(tt.column.Name)
+			if column == nil {
+				t.Fatalf("column %s.%s not found", tt.table, tt.column.Name)
+			}
+			if *column != tt.column {
+				t.Errorf("column = %+v, want %+v", *column, tt.column)
+			}
+		})
+	}
+
+	// CREATE INDEX statements are stored as rows of their own
+	indexes := table("orders").Indexes
+	if len(indexes) != 1 || indexes[0].Name != "idx_orders_status" || !slices.Equal(indexes[0].Columns, []string{"status"}) {
+		t.Errorf("orders indexes = %+v, want idx_orders_status on status", indexes)
+	}
+}
+
+// TestReadSQLiteDDLCorrupted checks that damaged files are reported instead of panicking or looping.
+func TestReadSQLiteDDLCorrupted(t *testing.T) {
+	fixture, err := os.ReadFile(sqliteFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func(data []byte) []byte
+	}{
+		{"not a database", func([]byte) []byte { return []byte("CREATE TABLE posts (id INTEGER PRIMARY KEY);") }},
+		{"truncated", func(data []byte) []byte { return data[:len(data)/2] }},
+		{"cell out of range", func(data []byte) []byte {
+			binary.BigEndian.PutUint16(data[112:], 0xffff)
+			return data
+		}},
+		{"page cycle", func(data []byte) []byte {
+			binary.BigEndian.PutUint32(data[108:], 1)
+			return data
+		}},
+		{"too many cells", func(data []byte) []byte {
+			binary.BigEndian.PutUint16(data[103:], 0xffff)
+			return data
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.db")
+			if err := os.WriteFile(path, tt.corrupt(slices.Clone(fixture)), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ReadSQLiteDDL(path); err == nil {
+				t.Error("ReadSQLiteDDL() succeeded, want an error")
+			}
+		})
+	}
+}
+
+// TestReadSQLiteDDLWriteAheadLog checks that databases with changes left in their write-ahead log are refused.
+func TestReadSQLiteDDLWriteAheadLog(t *testing.T) {
+	fixture, err := os.ReadFile(sqliteFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "app.db")
+	if err := os.WriteFile(path, fixture, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path+"-wal", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadSQLiteDDL(path); err != nil {
+		t.Errorf("ReadSQLiteDDL() with an empty log error = %v", err)
+	}
+	if err := os.WriteFile(path+"-wal", []byte{0x37, 0x7f, 0x06, 0x82}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadSQLiteDDL(path); err == nil {
+		t.Error("ReadSQLiteDDL() with changes in the log succeeded, want an error")
+	}
+}
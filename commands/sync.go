@@ -0,0 +1,286 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// SyncModels regenerates the layers derived from every domain model: the inbound model, its validator,
+// the outbound model and the mappers. Repositories, services and handlers hold user-owned code and are left untouched,
+// besides the request body of the handler tests and the records of the repository tests.
+// Models without generated layers are skipped, run scaffold for them first.
+func SyncModels() error {
+	models, err := ReadModels(modelsDomainPath)
+	if err != nil {
+		return fmt.Errorf("error reading models: %v", err)
+	}
+	structs := modelStructs(models)
+
+	for _, model := range models {
+		fileName := utils.ToCamelCase(model.Name)
+		if _, err := os.Stat(filepath.Join("internal/app/transport/inbound", fileName+".go")); err != nil {
+			continue
+		}
+
+		spec, options, err := structModelSpec(model, structs)
+		if err != nil {
+			fmt.Printf("%s: skipped, %v\n", model.Name, err)
+			continue
+		}
+
+		changes, err := syncModel(fileName, spec, options)
+		if err != nil {
+			return fmt.Errorf("error syncing model %s: %v", model.Name, err)
+		}
+		if len(changes) == 0 {
+			fmt.Printf("%s: up to date\n", model.Name)
+			continue
+		}
+		fmt.Printf("%s:\n", model.Name)
+		for _, change := range changes {
+			fmt.Printf("- %s\n", change)
+		}
+	}
+	return nil
+}
+
+// syncModel rewrites the derived files of a model and describes what changed in them.
+func syncModel(fileName string, spec ModelSpec, options ModelOptions) ([]string, error) {
+	inboundFilePath := fmt.Sprintf("internal/app/transport/inbound/%s.go", fileName)
+	validatorFilePath := fmt.Sprintf("internal/app/transport/inbound/%sValidator.go", fileName)
+	mapperFilePath := fmt.Sprintf("internal/app/transport/mapper/%sMapToModel.go", fileName)
+	outboundFilePath := fmt.Sprintf("internal/app/transport/outbound/%s.go", fileName)
+	outboundMapperFilePath := fmt.Sprintf("internal/app/transport/mapper/%sMapToOutbound.go", fileName)
+
+	var changes []string
+	previousFields := inboundFields(inboundFilePath, spec.Name)
+	changed, err := rewriteFile(inboundFilePath, func() error { return writeInboundModelFile(inboundFilePath, spec) })
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		fieldChanges := inboundFieldChanges(previousFields, spec.inboundFields())
+		if len(fieldChanges) == 0 {
+			fieldChanges = []string{"updated " + inboundFilePath}
+		}
+		changes = append(changes, fieldChanges...)
+	}
+
+	changed, err = rewriteFile(validatorFilePath, func() error { return writeValidatorFile(validatorFilePath, spec) })
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		changes = append(changes, "updated "+validatorFilePath)
+	}
+
+	changed, err = rewriteFile(mapperFilePath, func() error { return writeMapperFile(mapperFilePath, fileName, spec.Name) })
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		changes = append(changes, "updated "+mapperFilePath)
+	}
+
+	// Projects generated before the outbound model get it, their handlers can then map their responses
+	if err := os.MkdirAll(filepath.Dir(outboundFilePath), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("error creating outbound directory: %v", err)
+	}
+	changed, err = rewriteFile(outboundFilePath, func() error { return writeOutboundModelFile(outboundFilePath, spec, options) })
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		changes = append(changes, "updated "+outboundFilePath)
+	}
+
+	changed, err = rewriteFile(outboundMapperFilePath, func() error { return writeOutboundMapperFile(outboundMapperFilePath, spec.Name) })
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		changes = append(changes, "updated "+outboundMapperFilePath)
+	}
+
+	handlerTestFilePath := fmt.Sprintf("internal/app/adapter/handler/%sHandler_test.go", fileName)
+	changed, err = syncValidBody(handlerTestFilePath, spec)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		changes = append(changes, "updated the request body of "+handlerTestFilePath)
+	}
+
+	wiredName := wiredModelName(spec.Name)
+	repositoryTestFilePath := filepath.Join("internal", "app", "domain", "repository", wiredName, wiredName+"RepositoryImpl_test.go")
+	changed, err = syncRepositoryTestModel(repositoryTestFilePath, spec)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		changes = append(changes, "updated the unique values of the records of "+repositoryTestFilePath)
+	}
+	return changes, nil
+}
+
+// syncRepositoryTestModel replaces the function building the records of the generated repository tests
+// when the unique fields of the model changed. The rest of the test file is left as it is.
+func syncRepositoryTestModel(path string, spec ModelSpec) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, nil
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+	if err != nil {
+		return false, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	for _, decl := range file.Decls {
+		function, ok := decl.(*ast.FuncDecl)
+		if !ok || function.Recv != nil || function.Name.Name != "new"+spec.Name {
+			continue
+		}
+		start, end := fset.Position(function.Pos()).Offset, fset.Position(function.End()).Offset
+		if function.Doc != nil {
+			start = fset.Position(function.Doc.Pos()).Offset
+		}
+		previous := string(content[start:end]) + "\n"
+		current := repositoryTestModel(spec)
+		if previous == current {
+			return false, nil
+		}
+
+		updatedSet := token.NewFileSet()
+		source := string(content[:start]) + strings.TrimSuffix(current, "\n") + string(content[end:])
+		updated, err := parser.ParseFile(updatedSet, path, source, parser.ParseComments)
+		if err != nil {
+			return false, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		syncImports(updated)
+
+		var formatted bytes.Buffer
+		if err := format.Node(&formatted, updatedSet, updated); err != nil {
+			return false, fmt.Errorf("error formatting %s: %v", path, err)
+		}
+		return true, os.WriteFile(path, groupImports(formatted.Bytes()), 0644)
+	}
+	return false, nil
+}
+
+// syncValidBody replaces the request body sent by the generated handler tests when its keys no longer
+// match the fields it needs to pass the validator. The rest of the test file is left as it is.
+func syncValidBody(path string, spec ModelSpec) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, nil
+	}
+
+	body := sampleRequestBody(spec)
+	lines := strings.Split(string(content), "\n")
+	changed := false
+	for i, line := range lines {
+		if previous, ok := strings.CutPrefix(line, "\tvalidBody := "); ok {
+			previous, _ = strconv.Unquote(previous)
+			changed = !sameJSONKeys(previous, body)
+			lines[i] = "\tvalidBody := " + strconv.Quote(body)
+			break
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
+}
+
+// sameJSONKeys reports whether two JSON objects have the same keys.
+func sameJSONKeys(a, b string) bool {
+	var first, second map[string]json.RawMessage
+	if json.Unmarshal([]byte(a), &first) != nil || json.Unmarshal([]byte(b), &second) != nil || len(first) != len(second) {
+		return false
+	}
+	for key := range first {
+		if _, ok := second[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// rewriteFile runs write and reports whether it changed the content of path.
+func rewriteFile(path string, write func() error) (bool, error) {
+	previous, _ := os.ReadFile(path)
+	if err := write(); err != nil {
+		return false, fmt.Errorf("error writing %s: %v", path, err)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	return !bytes.Equal(previous, current), nil
+}
+
+// inboundFields returns the fields of the inbound struct declared in path, with their types, in order.
+// A missing or invalid file has no fields.
+func inboundFields(path, structName string) [][2]string {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil
+	}
+
+	var fields [][2]string
+	ast.Inspect(file, func(node ast.Node) bool {
+		typeSpec, ok := node.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != structName {
+			return true
+		}
+		if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					fields = append(fields, [2]string{name.Name, types.ExprString(field.Type)})
+				}
+			}
+		}
+		return false
+	})
+	return fields
+}
+
+// inboundFieldChanges describes the fields added, removed or retyped between two versions of an inbound struct.
+func inboundFieldChanges(previous [][2]string, fields []ModelField) []string {
+	previousTypes := map[string]string{}
+	for _, field := range previous {
+		previousTypes[field[0]] = field[1]
+	}
+
+	var changes []string
+	current := map[string]bool{}
+	for _, field := range fields {
+		current[field.Name] = true
+		previousType, existed := previousTypes[field.Name]
+		switch {
+		case !existed:
+			changes = append(changes, fmt.Sprintf("added field %s %s", field.Name, transportType(field)))
+		case previousType != transportType(field):
+			changes = append(changes, fmt.Sprintf("changed field %s from %s to %s", field.Name, previousType, transportType(field)))
+		}
+	}
+	for _, field := range previous {
+		if !current[field[0]] {
+			changes = append(changes, fmt.Sprintf("removed field %s", field[0]))
+		}
+	}
+	return changes
+}
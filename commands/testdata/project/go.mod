@@ -0,0 +1,8 @@
+module project
+
+go 1.23
+
+require (
+	github.com/gofiber/fiber/v2 v2.52.15
+	gorm.io/gorm v1.31.2
+)
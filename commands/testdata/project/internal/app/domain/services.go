@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"project/internal/infra/database"
+)
+
+type Services struct {
+}
+
+func NewServices(dbs *database.Databases) *Services {
+	services := &Services{}
+	return services
+}
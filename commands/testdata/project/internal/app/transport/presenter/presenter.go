@@ -0,0 +1,5 @@
+package presenter
+
+func Success(message string, data interface{}) map[string]interface{} {
+	return map[string]interface{}{"message": message, "data": data}
+}
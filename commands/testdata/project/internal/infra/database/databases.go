@@ -0,0 +1,14 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+type Databases struct {
+	Read  *gorm.DB
+	Write *gorm.DB
+}
+
+func (d *Databases) runMigrations(db *gorm.DB) {
+	db.AutoMigrate()
+}
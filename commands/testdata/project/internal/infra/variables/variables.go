@@ -0,0 +1,3 @@
+package variables
+
+func PrefixRoute() string { return "/api/v1" }
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"project/internal/app/adapter"
+	"project/internal/app/domain"
+	"project/internal/infra/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func main() {
+	s := domain.NewServices(&database.Databases{})
+	adapter.NewHandlers(s).Configure(fiber.New())
+}
@@ -0,0 +1,340 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lucassilveira96/silveirinha/utils"
+)
+
+// writeValidatorFile creates the Validate method of the inbound model, which checks the rules of its fields.
+// Models without rules get a Validate method that always passes, so every handler can call it.
+func writeValidatorFile(filePath string, spec ModelSpec) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	var checks, patterns strings.Builder
+	for _, field := range spec.inboundFields() {
+		checks.WriteString(fieldChecks(spec.Name, field, &patterns))
+	}
+
+	writer.WriteString("package inbound\n\n")
+
+	if checks.Len() == 0 {
+		writer.WriteString(fmt.Sprintf("// Validate checks the %[1]s input against the rules of its fields.\nfunc (i %[1]s) Validate() error {\n\treturn nil\n}\n", spec.Name))
+		writer.Flush()
+		return nil
+	}
+
+	// Only the packages the checks use are imported
+	body := patterns.String() + checks.String()
+	var imports []string
+	for _, pkg := range []string{"errors", "net/mail", "net/url", "regexp", "slices", "unicode/utf8"} {
+		name := pkg[strings.LastIndex(pkg, "/")+1:]
+		if pkg == "errors" || strings.Contains(body, name+".") {
+			imports = append(imports, fmt.Sprintf("\t%q\n", pkg))
+		}
+	}
+	writer.WriteString("import (\n" + strings.Join(imports, "") + ")\n\n")
+
+	if patterns.Len() > 0 {
+		writer.WriteString("var (\n" + patterns.String() + ")\n\n")
+	}
+
+	writer.WriteString(fmt.Sprintf("// Validate checks the %s input against the rules of its fields.\n", spec.Name))
+	writer.WriteString(fmt.Sprintf("func (i %s) Validate() error {\n\tvar errs []error\n", spec.Name))
+	writer.WriteString(checks.String())
+	writer.WriteString("\treturn errors.Join(errs...)\n}\n")
+
+	writer.Flush()
+	return nil
+}
+
+// fieldChecks returns the statements validating a field. Regular expressions are
+// declared once in patterns, as package variables named after the model and field.
+func fieldChecks(structName string, field ModelField, patterns *strings.Builder) string {
+	rules := field.Rules
+	name := field.JSONName()
+	isString := field.Type == "string"
+	isNumber := isIntegerType(field.Type) || strings.HasPrefix(field.Type, "float")
+
+	value := "i." + field.Name
+	if field.Nullable {
+		value = "*i." + field.Name
+	}
+
+	var checks []string
+	fail := func(condition, message string) {
+		checks = append(checks, fmt.Sprintf("if %s {\n\terrs = append(errs, errors.New(%s))\n}", condition, strconv.Quote(message)))
+	}
+
+	if isString {
+		if rules.MinLength != nil && *rules.MinLength > 0 {
+			fail(fmt.Sprintf("utf8.RuneCountInString(%s) < %d", value, *rules.MinLength), fmt.Sprintf("%s must be at least %d characters long", name, *rules.MinLength))
+		}
+		if rules.MaxLength != nil {
+			fail(fmt.Sprintf("utf8.RuneCountInString(%s) > %d", value, *rules.MaxLength), fmt.Sprintf("%s must be at most %d characters long", name, *rules.MaxLength))
+		}
+		if rules.Pattern != "" {
+			variable := utils.ToCamelCase(structName) + field.Name + "Pattern"
+			patterns.WriteString(fmt.Sprintf("\t%s = regexp.MustCompile(%s)\n", variable, strconv.Quote(rules.Pattern)))
+			fail(fmt.Sprintf("!%s.MatchString(%s)", variable, value), fmt.Sprintf("%s must match %s", name, rules.Pattern))
+		}
+		if len(rules.Enum) > 0 {
+			quoted := make([]string, len(rules.Enum))
+			for i, option := range rules.Enum {
+				quoted[i] = strconv.Quote(option)
+			}
+			fail(fmt.Sprintf("!slices.Contains([]string{%s}, %s)", strings.Join(quoted, ", "), value), fmt.Sprintf("%s must be one of %s", name, strings.Join(rules.Enum, ", ")))
+		}
+		switch rules.Format {
+		case "email":
+			fail(fmt.Sprintf("_, err := mail.ParseAddress(%s); err != nil", value), name+" must be a valid email address")
+		case "uri", "url":
+			fail(fmt.Sprintf("_, err := url.ParseRequestURI(%s); err != nil", value), name+" must be a valid URL")
+		case "uuid":
+			variable := utils.ToCamelCase(structName) + field.Name + "UUIDPattern"
+			patterns.WriteString(fmt.Sprintf("\t%s = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)\n", variable))
+			fail(fmt.Sprintf("!%s.MatchString(%s)", variable, value), name+" must be a valid UUID")
+		}
+	}
+	if field.Enum {
+		// Enums check themselves, JSON decoding already rejects the other values
+		fail(fmt.Sprintf("!i.%s.IsValid()", field.Name), fmt.Sprintf("%s must be one of %s", name, strings.Join(rules.Enum, ", ")))
+	}
+	if isNumber {
+		if rules.Minimum != nil {
+			fail(fmt.Sprintf("float64(%s) < %s", value, formatNumber(*rules.Minimum)), fmt.Sprintf("%s must be at least %s", name, formatNumber(*rules.Minimum)))
+		}
+		if rules.Maximum != nil {
+			fail(fmt.Sprintf("float64(%s) > %s", value, formatNumber(*rules.Maximum)), fmt.Sprintf("%s must be at most %s", name, formatNumber(*rules.Maximum)))
+		}
+	}
+
+	// Missing values are only detected for pointers, strings and enums, the checks run when a value is set
+	required := ""
+	if rules.Required && requiresInput(field) {
+		required = fmt.Sprintf("errs = append(errs, errors.New(%s))", strconv.Quote(name+" is required"))
+	}
+	if len(checks) == 0 && required == "" {
+		return ""
+	}
+
+	missing := fmt.Sprintf("i.%s == nil", field.Name)
+	present := fmt.Sprintf("i.%s != nil", field.Name)
+	if !field.Nullable && (isString || field.Enum) {
+		missing = fmt.Sprintf("i.%s == \"\"", field.Name)
+		present = fmt.Sprintf("i.%s != \"\"", field.Name)
+	}
+
+	var result strings.Builder
+	switch {
+	case required != "" && len(checks) > 0:
+		result.WriteString(fmt.Sprintf("\tif %s {\n\t\t%s\n\t} else {\n%s\t}\n", missing, required, indent(checks, 2)))
+	case required != "":
+		result.WriteString(fmt.Sprintf("\tif %s {\n\t\t%s\n\t}\n", missing, required))
+	case field.Nullable || isString || field.Enum:
+		result.WriteString(fmt.Sprintf("\tif %s {\n%s\t}\n", present, indent(checks, 2)))
+	default:
+		result.WriteString(indent(checks, 1))
+	}
+	return result.String()
+}
+
+// requiresInput reports whether a missing value of the field can be detected.
+func requiresInput(field ModelField) bool {
+	return field.Nullable || field.Type == "string" || field.Enum
+}
+
+// validateRequired checks that a missing value of a required field can be detected: a missing number,
+// boolean or time cannot be told apart from its zero value unless the field is nullable.
+func validateRequired(field ModelField) error {
+	if field.Rules.Required && !requiresInput(field) {
+		return fmt.Errorf("%s cannot be required, a missing %s is not told apart from its zero value, make it nullable to require it", field.Name, field.Type)
+	}
+	return nil
+}
+
+// hasRequiredFields reports whether any field fails validation when the input is empty.
+func hasRequiredFields(fields []ModelField) bool {
+	for _, field := range fields {
+		if field.Rules.Required && requiresInput(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// indent indents each line of the statements by depth tabs.
+func indent(statements []string, depth int) string {
+	prefix := strings.Repeat("\t", depth)
+	var result strings.Builder
+	for _, statement := range statements {
+		for _, line := range strings.Split(statement, "\n") {
+			result.WriteString(prefix + line + "\n")
+		}
+	}
+	return result.String()
+}
+
+// formatNumber renders a number without a trailing ".0" for whole values.
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// sampleRequestBody returns a JSON body passing the validation of the inbound model.
+// Required fields and fields with an example are filled, examples taking precedence over generated values.
+func sampleRequestBody(spec ModelSpec) string {
+	body := map[string]interface{}{}
+	for _, field := range spec.inboundFields() {
+		if !field.Rules.Required && field.Example == "" {
+			continue
+		}
+		body[field.JSONName()] = sampleValue(field)
+	}
+
+	content, err := json.Marshal(body)
+	if err != nil {
+		return "{}"
+	}
+	return string(content)
+}
+
+// sampleValue returns a value of the field type satisfying its rules.
+func sampleValue(field ModelField) interface{} {
+	rules := field.Rules
+	if fieldType, ok := LookupFieldType(field.Type); ok && len(fieldType.Sample) > 0 && field.Example == "" {
+		return fieldType.Sample
+	}
+	switch {
+	case field.Type == "bool":
+		if example, err := strconv.ParseBool(field.Example); err == nil {
+			return example
+		}
+		return true
+	case isIntegerType(field.Type) || strings.HasPrefix(field.Type, "float"):
+		if example, err := strconv.ParseFloat(field.Example, 64); err == nil {
+			return example
+		}
+		value := 1.0
+		if rules.Minimum != nil {
+			value = *rules.Minimum
+		} else if rules.Maximum != nil && *rules.Maximum < value {
+			value = *rules.Maximum
+		}
+		if isIntegerType(field.Type) {
+			value = math.Ceil(value)
+		}
+		return value
+	case field.Type == "time.Time":
+		if field.Example != "" {
+			return field.Example
+		}
+		return "2024-01-01T00:00:00Z"
+	}
+
+	if field.Example != "" {
+		return field.Example
+	}
+	if len(rules.Enum) > 0 {
+		return rules.Enum[0]
+	}
+	switch rules.Format {
+	case "email":
+		return "user@example.com"
+	case "uri", "url":
+		return "https://example.com"
+	case "uuid":
+		return "123e4567-e89b-12d3-a456-426614174000"
+	}
+	length := 1
+	if rules.MinLength != nil && *rules.MinLength > length {
+		length = *rules.MinLength
+	}
+	return strings.Repeat("a", length)
+}
+
+// rulesTag renders the rules of a field as the value of the rules struct tag of the domain model,
+// like "required,min=3,max=50,pattern=^[a-z]+$". The pattern comes last, since it may contain commas.
+func rulesTag(rules FieldRules) string {
+	var options []string
+	if rules.Required {
+		options = append(options, "required")
+	}
+	if rules.MinLength != nil {
+		options = append(options, fmt.Sprintf("min=%d", *rules.MinLength))
+	}
+	if rules.MaxLength != nil {
+		options = append(options, fmt.Sprintf("max=%d", *rules.MaxLength))
+	}
+	if rules.Minimum != nil {
+		options = append(options, "gte="+formatNumber(*rules.Minimum))
+	}
+	if rules.Maximum != nil {
+		options = append(options, "lte="+formatNumber(*rules.Maximum))
+	}
+	if len(rules.Enum) > 0 {
+		options = append(options, "oneof="+strings.Join(rules.Enum, "|"))
+	}
+	if rules.Format != "" {
+		options = append(options, "format="+rules.Format)
+	}
+	if rules.Pattern != "" {
+		options = append(options, "pattern="+rules.Pattern)
+	}
+	return strings.Join(options, ",")
+}
+
+// parseRulesTag reads the rules written by rulesTag. Unknown options are ignored.
+func parseRulesTag(tag string) FieldRules {
+	var rules FieldRules
+	for tag != "" {
+		option := tag
+		if strings.HasPrefix(option, "pattern=") {
+			rules.Pattern = strings.TrimPrefix(option, "pattern=")
+			break
+		}
+		if index := strings.Index(tag, ","); index >= 0 {
+			option, tag = tag[:index], tag[index+1:]
+		} else {
+			tag = ""
+		}
+
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "required":
+			rules.Required = true
+		case "min", "max":
+			if number, err := strconv.Atoi(value); err == nil {
+				if key == "min" {
+					rules.MinLength = &number
+				} else {
+					rules.MaxLength = &number
+				}
+			}
+		case "gte", "lte":
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				if key == "gte" {
+					rules.Minimum = &number
+				} else {
+					rules.Maximum = &number
+				}
+			}
+		case "oneof":
+			rules.Enum = strings.Split(value, "|")
+		case "format":
+			rules.Format = value
+		}
+	}
+	return rules
+}
@@ -0,0 +1,28 @@
+package commands
+
+import "testing"
+
+func TestValidateRequired(t *testing.T) {
+	tests := []struct {
+		field   ModelField
+		wantErr bool
+	}{
+		{field: ModelField{Name: "Name", Type: "string"}},
+		{field: ModelField{Name: "Name", Type: "string", Rules: FieldRules{Required: true}}},
+		{field: ModelField{Name: "Status", Type: "PostStatus", Enum: true, Rules: FieldRules{Required: true}}},
+		{field: ModelField{Name: "Age", Type: "int", Nullable: true, Rules: FieldRules{Required: true}}},
+		{field: ModelField{Name: "Age", Type: "int"}},
+		{field: ModelField{Name: "Age", Type: "int", Rules: FieldRules{Required: true}}, wantErr: true},
+		{field: ModelField{Name: "Price", Type: "float64", Rules: FieldRules{Required: true}}, wantErr: true},
+		{field: ModelField{Name: "Active", Type: "bool", Rules: FieldRules{Required: true}}, wantErr: true},
+		{field: ModelField{Name: "BornAt", Type: "time.Time", Rules: FieldRules{Required: true}}, wantErr: true},
+		{field: ModelField{Name: "AuthorId", Type: "uuid.UUID", Relation: "Author", Rules: FieldRules{Required: true}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field.Name+" "+tt.field.Type, func(t *testing.T) {
+			if err := validateRequired(tt.field); (err != nil) != tt.wantErr {
+				t.Errorf("validateRequired() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
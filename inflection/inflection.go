@@ -0,0 +1,296 @@
+// Package inflection converts names between the spellings used for Go types and variables,
+// tables, routes and Swagger tags, and between the singular and plural forms of their last word.
+package inflection
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// acronyms are the words written in capitals in Pascal and camel case names. ID is left out,
+// so foreign keys read like the AuthorId ones generated by the model prompts.
+var acronyms = map[string]bool{}
+
+// irregulars maps the singular words whose plural follows no rule to their plural, and back.
+var (
+	irregularPlurals   = map[string]string{}
+	irregularSingulars = map[string]string{}
+)
+
+// uncountables are the words whose singular and plural are the same.
+var uncountables = map[string]bool{}
+
+// rule rewrites a word matching pattern with replacement. Rules are tried in order.
+type rule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+var pluralRules, singularRules []rule
+
+func init() {
+	AddAcronym("API", "CPU", "CSS", "DNS", "HTML", "HTTP", "HTTPS", "IP", "JSON", "JWT", "SQL", "TCP", "UDP", "ULID", "URI", "URL", "UUID", "XML")
+	AddUncountable("data", "equipment", "feedback", "fish", "information", "jeans", "metadata", "money", "news", "police", "rice", "series", "sheep", "software", "species")
+	AddIrregular("child", "children")
+	AddIrregular("foot", "feet")
+	AddIrregular("goose", "geese")
+	AddIrregular("man", "men")
+	AddIrregular("move", "moves")
+	AddIrregular("person", "people")
+	AddIrregular("sex", "sexes")
+	AddIrregular("tooth", "teeth")
+	AddIrregular("woman", "women")
+
+	pluralRules = compileRules([][2]string{
+		{`(quiz)$`, "${1}zes"},
+		{`^(oxen)$`, "${1}"},
+		{`^(ox)$`, "${1}en"},
+		{`^(m|l)(ice|ouse)$`, "${1}ice"},
+		{`(matr|vert|ind)(ix|ex)$`, "${1}ices"},
+		{`(x|ch|ss|sh)$`, "${1}es"},
+		{`([^aeiouy]|qu)y$`, "${1}ies"},
+		{`(hive)$`, "${1}s"},
+		{`(?:([^f])fe|([lr])f)$`, "${1}${2}ves"},
+		{`sis$`, "ses"},
+		{`([ti])a$`, "${1}a"},
+		{`([ti])um$`, "${1}a"},
+		{`(buffal|tomat|potat|her)o$`, "${1}oes"},
+		{`(bu)s$`, "${1}ses"},
+		{`(alias|status|campus)$`, "${1}es"},
+		{`(octop|vir)(us|i)$`, "${1}i"},
+		{`^(ax|test)is$`, "${1}es"},
+		{`s$`, "s"},
+		{`$`, "s"},
+	})
+	singularRules = compileRules([][2]string{
+		{`(database)s$`, "${1}"},
+		{`(quiz)zes$`, "${1}"},
+		{`(matr)ices$`, "${1}ix"},
+		{`(vert|ind)ices$`, "${1}ex"},
+		{`^(ox)en$`, "${1}"},
+		{`(alias|status|campus)(es)?$`, "${1}"},
+		{`(octop|vir)(us|i)$`, "${1}us"},
+		{`^(a)x[ie]s$`, "${1}xis"},
+		{`(cris|test)(is|es)$`, "${1}is"},
+		{`(shoe)s$`, "${1}"},
+		{`(o)es$`, "${1}"},
+		{`(bus)(es)?$`, "${1}"},
+		{`^(m|l)ice$`, "${1}ouse"},
+		{`(x|ch|ss|sh)es$`, "${1}"},
+		{`(m)ovies$`, "${1}ovie"},
+		{`([^aeiouy]|qu)ies$`, "${1}y"},
+		{`([lr])ves$`, "${1}f"},
+		{`(tive|hive)s$`, "${1}"},
+		{`([^f])ves$`, "${1}fe"},
+		{`(analy|ba|diagno|parenthe|progno|synop|the)(sis|ses)$`, "${1}sis"},
+		{`([ti])a$`, "${1}um"},
+		{`(ss|us)$`, "${1}"},
+		{`s$`, ""},
+	})
+}
+
+func compileRules(rules [][2]string) []rule {
+	compiled := make([]rule, 0, len(rules))
+	for _, r := range rules {
+		compiled = append(compiled, rule{regexp.MustCompile(r[0]), r[1]})
+	}
+	return compiled
+}
+
+// AddAcronym registers words written in capitals in Pascal and camel case names, like "SKU" in "ProductSKU".
+func AddAcronym(words ...string) {
+	for _, word := range words {
+		acronyms[strings.ToLower(word)] = true
+	}
+}
+
+// AddIrregular registers a word whose plural follows no rule, like "person" and "people".
+func AddIrregular(singular, plural string) {
+	singular, plural = strings.ToLower(singular), strings.ToLower(plural)
+	irregularPlurals[singular] = plural
+	irregularSingulars[plural] = singular
+}
+
+// AddUncountable registers words whose singular and plural are the same, like "equipment".
+func AddUncountable(words ...string) {
+	for _, word := range words {
+		uncountables[strings.ToLower(word)] = true
+	}
+}
+
+// Words splits a name on separators, on lowercase to uppercase changes and before the last capital
+// of a run of capitals followed by a lowercase letter, unless it is the "s" of a plural acronym.
+// Digits stay with the word they follow: "userID", "HTTPServer", "user-profile", "UserIDs" and
+// "address2Line" give [user ID], [HTTP Server], [user profile], [User IDs] and [address2 Line].
+func Words(name string) []string {
+	runes := []rune(name)
+	var words []string
+	for _, span := range wordSpans(runes) {
+		words = append(words, string(runes[span[0]:span[1]]))
+	}
+	return words
+}
+
+// wordSpans returns the start and end of each word of a name.
+func wordSpans(runes []rune) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				spans = append(spans, [2]int{start, i})
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !isPluralAcronymEnd(runes, i+1)
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				spans = append(spans, [2]int{start, i})
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(runes)})
+	}
+	return spans
+}
+
+// isPluralAcronymEnd reports whether the rune at i is the "s" ending a word after capitals, like in "IDs".
+func isPluralAcronymEnd(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
+
+// Pascalize returns the PascalCase spelling of a name: "order_item" gives "OrderItem" and "userID" gives "UserID".
+// Registered acronyms, and words written in capitals in a name that is not entirely in capitals, stay in capitals.
+func Pascalize(name string) string {
+	var result strings.Builder
+	for _, word := range casedWords(name) {
+		result.WriteString(word)
+	}
+	return result.String()
+}
+
+// Camelize returns the camelCase spelling of a name: "OrderItem" gives "orderItem" and "HTTPServer" gives "httpServer".
+func Camelize(name string) string {
+	words := casedWords(name)
+	if len(words) == 0 {
+		return ""
+	}
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// Underscore returns the snake_case spelling of a name: "UserID" gives "user_id", like the columns GORM derives.
+func Underscore(name string) string {
+	return strings.ToLower(strings.Join(Words(name), "_"))
+}
+
+// Dasherize returns the kebab-case spelling of a name, used in routes: "OrderItem" gives "order-item".
+func Dasherize(name string) string {
+	return strings.ToLower(strings.Join(Words(name), "-"))
+}
+
+// casedWords returns the words of a name capitalized for Pascal and camel case.
+func casedWords(name string) []string {
+	keepCapitals := strings.IndexFunc(name, unicode.IsLower) >= 0
+	words := Words(name)
+	for i, word := range words {
+		lower := strings.ToLower(word)
+		switch {
+		case acronyms[lower]:
+			words[i] = strings.ToUpper(word)
+		case strings.HasSuffix(lower, "s") && acronyms[strings.TrimSuffix(lower, "s")]:
+			words[i] = strings.ToUpper(strings.TrimSuffix(word, "s")) + "s"
+		case keepCapitals && (isUpperWord(word) || isUpperWord(strings.TrimSuffix(word, "s"))):
+		default:
+			words[i] = capitalize(lower)
+		}
+	}
+	return words
+}
+
+// Pluralize returns the name with its last word in the plural: "Category" gives "Categories",
+// "order_item" gives "order_items" and "SalesPerson" gives "SalesPeople".
+func Pluralize(name string) string {
+	return inflectLastWord(name, true)
+}
+
+// Singularize returns the name with its last word in the singular: "order_items" gives "order_item".
+func Singularize(name string) string {
+	return inflectLastWord(name, false)
+}
+
+func inflectLastWord(name string, plural bool) string {
+	runes := []rune(name)
+	spans := wordSpans(runes)
+	if len(spans) == 0 {
+		return name
+	}
+	last := spans[len(spans)-1]
+	word := string(runes[last[0]:last[1]])
+	return string(runes[:last[0]]) + inflectWord(word, plural) + string(runes[last[1]:])
+}
+
+// inflectWord applies the first matching irregular or rule to a word, keeping its capitalization.
+func inflectWord(word string, plural bool) string {
+	lower := strings.ToLower(word)
+	if uncountables[lower] {
+		return word
+	}
+
+	// Acronyms take a lowercase "s" in the plural, like "IDs"
+	trimmed := strings.TrimSuffix(word, "s")
+	switch {
+	case isUpperWord(word):
+		if plural {
+			return word + "s"
+		}
+		return word
+	case trimmed != word && isUpperWord(trimmed):
+		if plural {
+			return word
+		}
+		return trimmed
+	}
+
+	irregulars, rules := irregularPlurals, pluralRules
+	if !plural {
+		irregulars, rules = irregularSingulars, singularRules
+	}
+	inflected, ok := irregulars[lower]
+	if !ok {
+		inflected = lower
+		for _, r := range rules {
+			if r.pattern.MatchString(lower) {
+				inflected = r.pattern.ReplaceAllString(lower, r.replacement)
+				break
+			}
+		}
+	}
+
+	if unicode.IsUpper([]rune(word)[0]) {
+		return capitalize(inflected)
+	}
+	return inflected
+}
+
+// isUpperWord reports whether a word of more than one letter is written in capitals.
+func isUpperWord(word string) bool {
+	return len(word) > 1 && strings.ToUpper(word) == word
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
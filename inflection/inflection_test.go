@@ -0,0 +1,157 @@
+package inflection
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"post", "posts"},
+		{"Category", "Categories"},
+		{"order_item", "order_items"},
+		{"OrderItem", "OrderItems"},
+		{"box", "boxes"},
+		{"church", "churches"},
+		{"address", "addresses"},
+		{"quiz", "quizzes"},
+		{"status", "statuses"},
+		{"knife", "knives"},
+		{"half", "halves"},
+		{"analysis", "analyses"},
+		{"matrix", "matrices"},
+		{"index", "indices"},
+		{"mouse", "mice"},
+		{"ox", "oxen"},
+		{"potato", "potatoes"},
+		{"octopus", "octopi"},
+		{"medium", "media"},
+		{"day", "days"},
+		{"person", "people"},
+		{"SalesPerson", "SalesPeople"},
+		{"child", "children"},
+		{"Woman", "Women"},
+		{"equipment", "equipment"},
+		{"UserData", "UserData"},
+		{"series", "series"},
+		{"ID", "IDs"},
+		{"userID", "userIDs"},
+		{"IDs", "IDs"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Pluralize(tt.name); got != tt.want {
+				t.Errorf("Pluralize(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSingularize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"posts", "post"},
+		{"Categories", "Category"},
+		{"order_items", "order_item"},
+		{"boxes", "box"},
+		{"churches", "church"},
+		{"addresses", "address"},
+		{"quizzes", "quiz"},
+		{"statuses", "status"},
+		{"status", "status"},
+		{"knives", "knife"},
+		{"halves", "half"},
+		{"analyses", "analysis"},
+		{"matrices", "matrix"},
+		{"indices", "index"},
+		{"mice", "mouse"},
+		{"oxen", "ox"},
+		{"potatoes", "potato"},
+		{"shoes", "shoe"},
+		{"movies", "movie"},
+		{"buses", "bus"},
+		{"databases", "database"},
+		{"media", "medium"},
+		{"people", "person"},
+		{"SalesPeople", "SalesPerson"},
+		{"children", "child"},
+		{"news", "news"},
+		{"Metadata", "Metadata"},
+		{"IDs", "ID"},
+		{"userIDs", "userID"},
+		{"ID", "ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Singularize(tt.name); got != tt.want {
+				t.Errorf("Singularize(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"userID", []string{"user", "ID"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"user-profile", []string{"user", "profile"}},
+		{"UserIDs", []string{"User", "IDs"}},
+		{"address2Line", []string{"address2", "Line"}},
+		{"order_item", []string{"order", "item"}},
+		{"  spaced  name ", []string{"spaced", "name"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Words(tt.name); !slices.Equal(got, tt.want) {
+				t.Errorf("Words(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCases(t *testing.T) {
+	tests := []struct {
+		name       string
+		pascal     string
+		camel      string
+		underscore string
+		dasherize  string
+	}{
+		{"order_item", "OrderItem", "orderItem", "order_item", "order-item"},
+		{"OrderItem", "OrderItem", "orderItem", "order_item", "order-item"},
+		{"userID", "UserID", "userID", "user_id", "user-id"},
+		{"user_id", "UserId", "userId", "user_id", "user-id"},
+		{"HTTPServer", "HTTPServer", "httpServer", "http_server", "http-server"},
+		{"api_url", "APIURL", "apiURL", "api_url", "api-url"},
+		{"user_ids", "UserIds", "userIds", "user_ids", "user-ids"},
+		{"ProductSKU", "ProductSKU", "productSKU", "product_sku", "product-sku"},
+		{"PRODUCT_NAME", "ProductName", "productName", "product_name", "product-name"},
+		{"first name", "FirstName", "firstName", "first_name", "first-name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Pascalize(tt.name); got != tt.pascal {
+				t.Errorf("Pascalize(%q) = %q, want %q", tt.name, got, tt.pascal)
+			}
+			if got := Camelize(tt.name); got != tt.camel {
+				t.Errorf("Camelize(%q) = %q, want %q", tt.name, got, tt.camel)
+			}
+			if got := Underscore(tt.name); got != tt.underscore {
+				t.Errorf("Underscore(%q) = %q, want %q", tt.name, got, tt.underscore)
+			}
+			if got := Dasherize(tt.name); got != tt.dasherize {
+				t.Errorf("Dasherize(%q) = %q, want %q", tt.name, got, tt.dasherize)
+			}
+		})
+	}
+}
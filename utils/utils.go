@@ -1,52 +1,23 @@
 package utils
 
-import (
-	"strings"
-	"unicode"
-)
+import "github.com/lucassilveira96/silveirinha/inflection"
 
-// ToPascalCase converts a string to PascalCase
+// ToPascalCase converts a string to PascalCase, like "order_item" to "OrderItem"
 func ToPascalCase(input string) string {
-	// Capitalize the first letter of the input and keep the rest as is
-	if len(input) > 0 {
-		input = strings.ToUpper(string(input[0])) + input[1:]
-	}
-	return input
+	return inflection.Pascalize(input)
 }
 
-// ToCamelCase converts a string to camelCase
+// ToCamelCase converts a string to camelCase, like "OrderItem" to "orderItem"
 func ToCamelCase(input string) string {
-	// Make the first letter lowercase (for camelCase)
-	if len(input) > 0 {
-		input = strings.ToLower(string(input[0])) + input[1:]
-	}
-	return input
+	return inflection.Camelize(input)
 }
 
-// ToSnakeCase converts a string to snake_case
+// ToSnakeCase converts a string to snake_case, like "UserID" to "user_id"
 func ToSnakeCase(input string) string {
-	var result strings.Builder
-	for i, r := range input {
-		// If the rune is uppercase and not the first one, insert an underscore
-		if i > 0 && unicode.IsUpper(r) {
-			result.WriteRune('_')
-		}
-		// Add the current rune as lowercase
-		result.WriteRune(unicode.ToLower(r))
-	}
-	return result.String()
+	return inflection.Underscore(input)
 }
 
-// ToUrlCase converts a string to url-case
+// ToUrlCase converts a string to url-case, like "OrderItem" to "order-item"
 func ToUrlCase(input string) string {
-	var result strings.Builder
-	for i, r := range input {
-		// If the rune is uppercase and not the first one, insert an underscore
-		if i > 0 && unicode.IsUpper(r) {
-			result.WriteRune('-')
-		}
-		// Add the current rune as lowercase
-		result.WriteRune(unicode.ToLower(r))
-	}
-	return result.String()
+	return inflection.Dasherize(input)
 }